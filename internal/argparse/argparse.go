@@ -10,7 +10,10 @@
 	"github.com/anmitsu/go-shlex"
 )
 
-func Parse(args []string) (ParsedArgs, error) { //nolint: cyclop
+// Parse parses command line arguments of the script. Parameters define
+// how repeated values are splitted and ordered; unknown parameters are
+// splitted and sorted.
+func Parse(args []string, parameters map[string]config.Parameter) (ParsedArgs, error) { //nolint: cyclop
 	parsed := ParsedArgs{
 		Parameters: make(map[string][]string),
 		Flags:      make(map[string]bool),
@@ -67,7 +70,7 @@ func Parse(args []string) (ParsedArgs, error) { //nolint: cyclop
 				return parsed, fmt.Errorf("incorrect parameter %s", arg)
 			}
 
-			values, err := shlex.Split(value, true)
+			values, err := splitValue(parameters[name], value)
 			if err != nil {
 				return parsed, fmt.Errorf("cannot split parameter %s: %w", arg, err)
 			}
@@ -78,9 +81,36 @@ func Parse(args []string) (ParsedArgs, error) { //nolint: cyclop
 		}
 	}
 
-	for _, values := range parsed.Parameters {
-		sort.Strings(values)
+	for name, values := range parsed.Parameters {
+		if param, ok := parameters[name]; !ok || !param.List().KeepOrder {
+			sort.Strings(values)
+		}
 	}
 
 	return parsed, nil
 }
+
+// ParseValue converts a single value of a parameter into a list of
+// values the same way Parse does it for a command line argument.
+func ParseValue(param config.Parameter, value string) ([]string, error) {
+	values, err := splitValue(param, value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot split value: %w", err)
+	}
+
+	if param == nil || !param.List().KeepOrder {
+		sort.Strings(values)
+	}
+
+	return values, nil
+}
+
+// splitValue splits a value of a parameter if required. param is nil
+// for unknown parameters.
+func splitValue(param config.Parameter, value string) ([]string, error) {
+	if param != nil && !param.List().Split {
+		return []string{value}, nil
+	}
+
+	return shlex.Split(value, true)
+}
@@ -1,9 +1,11 @@
 package argparse_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/9seconds/chore/internal/argparse"
+	"github.com/9seconds/chore/internal/script/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -13,7 +15,7 @@ type ParseTestSuite struct {
 }
 
 func (suite *ParseTestSuite) TestInvalidArgument() {
-	_, err := argparse.Parse([]string{"a\xc5z"})
+	_, err := argparse.Parse([]string{"a\xc5z"}, nil)
 	suite.ErrorContains(err, "is not valid UTF-8 string")
 }
 
@@ -23,7 +25,7 @@ func (suite *ParseTestSuite) TestUnexpectedFlag() {
 
 		suite.T().Run(testName, func(t *testing.T) {
 			_, err := argparse.Parse(
-				[]string{"arg", testName})
+				[]string{"arg", testName}, nil)
 			assert.ErrorContains(t, err, "unexpected flag")
 		})
 	}
@@ -35,19 +37,19 @@ func (suite *ParseTestSuite) TestIncorrectFlag() {
 
 		suite.T().Run(testValue, func(t *testing.T) {
 			_, err := argparse.Parse(
-				[]string{testValue})
+				[]string{testValue}, nil)
 			assert.ErrorContains(t, err, "incorrect flag")
 		})
 	}
 }
 
 func (suite *ParseTestSuite) TestUnexpectedParameter() {
-	_, err := argparse.Parse([]string{"arg", "c=1"})
+	_, err := argparse.Parse([]string{"arg", "c=1"}, nil)
 	suite.ErrorContains(err, "unexpected parameter")
 }
 
 func (suite *ParseTestSuite) TestIncorrectParameter() {
-	_, err := argparse.Parse([]string{"=1"})
+	_, err := argparse.Parse([]string{"=1"}, nil)
 	suite.ErrorContains(err, "incorrect parameter")
 }
 
@@ -67,7 +69,7 @@ func (suite *ParseTestSuite) TestMixed() {
 		"arg2",
 		":_j",
 		":k=v",
-	})
+	}, nil)
 
 	suite.NoError(err)
 	suite.Equal(map[string][]string{
@@ -85,20 +87,81 @@ func (suite *ParseTestSuite) TestMixed() {
 
 func (suite *ParseTestSuite) TestExplicitPositionals() {
 	suite.T().Run("empty", func(t *testing.T) {
-		parsed, err := argparse.Parse([]string{})
+		parsed, err := argparse.Parse([]string{}, nil)
 		assert.NoError(t, err)
 		assert.False(t, parsed.IsPositionalTime())
 	})
 
 	suite.T().Run("non-empty", func(t *testing.T) {
 		parsed, err := argparse.Parse(
-			[]string{"_x", "--", "a", ":--", "b", "-c"})
+			[]string{"_x", "--", "a", ":--", "b", "-c"}, nil)
 		assert.NoError(t, err)
 		assert.True(t, parsed.IsPositionalTime())
 		assert.Equal(t, []string{"a", ":--", "b", "-c"}, parsed.Positional)
 	})
 }
 
+func (suite *ParseTestSuite) TestListSpec() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.ordered]
+type = "string"
+spec = { keep_order = "true" }
+
+[parameters.literal]
+type = "string"
+spec = { split = "false" }`))
+	suite.NoError(err)
+
+	parsed, err := argparse.Parse([]string{
+		"ordered=c b",
+		"ordered=a",
+		"literal=z y",
+		"literal=x",
+		"other=c b",
+	}, conf.Parameters)
+	suite.NoError(err)
+	suite.Equal(map[string][]string{
+		"ordered": {"c", "b", "a"},
+		"literal": {"x", "z y"},
+		"other":   {"b", "c"},
+	}, parsed.Parameters)
+}
+
+func (suite *ParseTestSuite) TestParseValue() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.ordered]
+type = "string"
+spec = { keep_order = "true" }
+
+[parameters.literal]
+type = "string"
+spec = { split = "false" }
+
+[parameters.sorted]
+type = "string"`))
+	suite.NoError(err)
+
+	testTable := map[string][]string{
+		"ordered": {"c", "b a"},
+		"literal": {"c 'b a'"},
+		"sorted":  {"b a", "c"},
+	}
+
+	for name, expected := range testTable {
+		name := name
+		expected := expected
+
+		suite.T().Run(name, func(t *testing.T) {
+			values, err := argparse.ParseValue(conf.Parameters[name], "c 'b a'")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, values)
+		})
+	}
+
+	_, err = argparse.ParseValue(conf.Parameters["sorted"], "'a")
+	suite.Error(err)
+}
+
 func TestParse(t *testing.T) {
 	suite.Run(t, &ParseTestSuite{})
 }
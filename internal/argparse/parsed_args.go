@@ -22,6 +22,10 @@
 
 	FlagEnabled = "1"
 
+	// MaskedValue replaces values of sensitive arguments where they are
+	// shown to user or stored.
+	MaskedValue = "***"
+
 	SerializePrefixPositional   = "0"
 	SerializePrefixFlag         = "1"
 	SerializePrefixParameter    = "2"
@@ -127,10 +131,8 @@ func (p ParsedArgs) Validate( //nolint: cyclop
 		}
 	}
 
-	for name, flag := range flags {
-		if _, ok := p.Flags[name]; !ok && flag.Required() {
-			return fmt.Errorf("mandatory flag %s was not provided", name)
-		}
+	if missing := p.MissingFlags(flags); len(missing) > 0 {
+		return fmt.Errorf("mandatory flag %s was not provided", missing[0])
 	}
 
 	for name := range p.Parameters {
@@ -139,9 +141,13 @@ func (p ParsedArgs) Validate( //nolint: cyclop
 		}
 	}
 
-	for name, parameter := range parameters {
-		if _, ok := p.Parameters[name]; !ok && parameter.Required() {
-			return fmt.Errorf("mandatory parameter %s was not provided", name)
+	if missing := p.MissingParameters(parameters); len(missing) > 0 {
+		return fmt.Errorf("mandatory parameter %s was not provided", missing[0])
+	}
+
+	for _, name := range binutils.SortedMapKeys(p.Parameters) {
+		if err := parameters[name].List().Validate(p.Parameters[name]); err != nil {
+			return fmt.Errorf("invalid values for parameter %s: %w", name, err)
 		}
 	}
 
@@ -176,6 +182,172 @@ func (p ParsedArgs) Validate( //nolint: cyclop
 	return <-errChan
 }
 
+// NamedPositional maps positional arguments to names from a schema. A
+// variadic argument gets all remaining values. Arguments which were not
+// provided are absent.
+func (p ParsedArgs) NamedPositional(positional []config.Positional) map[string][]string {
+	named := make(map[string][]string, len(positional))
+
+	for idx, arg := range positional {
+		switch {
+		case idx >= len(p.Positional):
+			return named
+		case arg.Variadic:
+			named[arg.Name] = p.Positional[idx:]
+		default:
+			named[arg.Name] = p.Positional[idx : idx+1]
+		}
+	}
+
+	return named
+}
+
+// Masked returns a copy of arguments where values of sensitive
+// parameters and positionals are replaced with MaskedValue.
+func (p ParsedArgs) Masked(parameters map[string]config.Parameter, positional []config.Positional) ParsedArgs {
+	masked := ParsedArgs{
+		Flags:              p.Flags,
+		ExplicitPositional: p.ExplicitPositional,
+	}
+
+	if p.Parameters != nil {
+		masked.Parameters = make(map[string][]string, len(p.Parameters))
+	}
+
+	for key, values := range p.Parameters {
+		if param, ok := parameters[key]; ok && param.Sensitive() {
+			values = make([]string, len(values))
+
+			for idx := range values {
+				values[idx] = MaskedValue
+			}
+		}
+
+		masked.Parameters[key] = values
+	}
+
+	if p.Positional != nil {
+		masked.Positional = make([]string, len(p.Positional))
+		copy(masked.Positional, p.Positional)
+	}
+
+	for idx, arg := range positional {
+		if idx >= len(masked.Positional) {
+			break
+		}
+
+		if arg.Parameter == nil || !arg.Parameter.Sensitive() {
+			continue
+		}
+
+		end := idx + 1
+		if arg.Variadic {
+			end = len(masked.Positional)
+		}
+
+		for i := idx; i < end; i++ {
+			masked.Positional[i] = MaskedValue
+		}
+	}
+
+	return masked
+}
+
+// ValidatePositional checks positional arguments against a schema. If
+// schema is empty, any positional arguments are accepted.
+func (p ParsedArgs) ValidatePositional(ctx context.Context, positional []config.Positional) error {
+	if len(positional) == 0 {
+		return nil
+	}
+
+	if !positional[len(positional)-1].Variadic && len(p.Positional) > len(positional) {
+		return fmt.Errorf(
+			"too many positional arguments: expected at most %d, got %d",
+			len(positional),
+			len(p.Positional))
+	}
+
+	named := p.NamedPositional(positional)
+
+	for _, arg := range positional {
+		values, ok := named[arg.Name]
+
+		switch {
+		case !ok && arg.Parameter.Required():
+			return fmt.Errorf("mandatory positional argument %s was not provided", arg.Name)
+		case !ok:
+			continue
+		case arg.Variadic:
+			if err := arg.Parameter.List().Validate(values); err != nil {
+				return fmt.Errorf("invalid values for positional argument %s: %w", arg.Name, err)
+			}
+		}
+
+		for _, value := range values {
+			if err := arg.Parameter.Validate(ctx, value); err != nil {
+				return fmt.Errorf("invalid value for positional argument %s: %w", arg.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyDefaults sets default values of flags and parameters which were
+// not provided.
+func (p ParsedArgs) ApplyDefaults(
+	flags map[string]config.Flag,
+	parameters map[string]config.Parameter,
+) {
+	for name, flag := range flags {
+		if _, ok := p.Flags[name]; ok {
+			continue
+		}
+
+		if value, ok := flag.Default(); ok {
+			p.Flags[name] = value
+		}
+	}
+
+	for name, param := range parameters {
+		if _, ok := p.Parameters[name]; ok {
+			continue
+		}
+
+		if values := param.Default(); values != nil {
+			p.Parameters[name] = append([]string{}, values...)
+		}
+	}
+}
+
+// MissingFlags returns sorted names of required flags which were not
+// provided.
+func (p ParsedArgs) MissingFlags(flags map[string]config.Flag) []string {
+	missing := []string{}
+
+	for _, name := range binutils.SortedMapKeys(flags) {
+		if _, ok := p.Flags[name]; !ok && flags[name].Required() {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// MissingParameters returns sorted names of required parameters which
+// were not provided.
+func (p ParsedArgs) MissingParameters(parameters map[string]config.Parameter) []string {
+	missing := []string{}
+
+	for _, name := range binutils.SortedMapKeys(parameters) {
+		if _, ok := p.Parameters[name]; !ok && parameters[name].Required() {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
 func (p ParsedArgs) IsPositionalTime() bool {
 	return p.ExplicitPositional || len(p.Positional) > 0
 }
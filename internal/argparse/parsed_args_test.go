@@ -1,6 +1,7 @@
 package argparse_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/9seconds/chore/internal/argparse"
@@ -103,6 +104,234 @@ func (suite *ParsedArgsTestSuite) TestCannotFindRequiredFlag() {
 		"mandatory flag")
 }
 
+func (suite *ParsedArgsTestSuite) TestMissing() {
+	args := argparse.ParsedArgs{
+		Parameters: map[string][]string{
+			"int1": {"1"},
+		},
+		Flags: map[string]bool{
+			"flag2": true,
+		},
+	}
+
+	suite.Equal([]string{"flag1"}, args.MissingFlags(suite.flags))
+	suite.Equal([]string{"json1"}, args.MissingParameters(suite.params))
+
+	args.Flags["flag1"] = false
+	args.Parameters["json1"] = []string{"{}"}
+
+	suite.Empty(args.MissingFlags(suite.flags))
+	suite.Empty(args.MissingParameters(suite.params))
+}
+
+func (suite *ParsedArgsTestSuite) TestApplyDefaults() {
+	conf, err := config.Parse(strings.NewReader(`
+[flags.flag1]
+default = true
+
+[flags.flag2]
+default = true
+
+[parameters.param1]
+type = "string"
+default = "x"
+
+[parameters.param2]
+type = "string"
+default_list = ["y", "z"]`))
+	suite.NoError(err)
+
+	args := argparse.ParsedArgs{
+		Parameters: map[string][]string{
+			"param1": {"1"},
+		},
+		Flags: map[string]bool{
+			"flag2": false,
+		},
+	}
+
+	args.ApplyDefaults(conf.Flags, conf.Parameters)
+
+	suite.Equal(map[string]bool{
+		"flag1": true,
+		"flag2": false,
+	}, args.Flags)
+	suite.Equal(map[string][]string{
+		"param1": {"1"},
+		"param2": {"y", "z"},
+	}, args.Parameters)
+}
+
+func (suite *ParsedArgsTestSuite) TestValidateListSpec() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.hosts]
+type = "string"
+spec = { min_count = "2", max_count = "2", unique = "true" }`))
+	suite.NoError(err)
+
+	testTable := map[string]struct {
+		values []string
+		err    string
+	}{
+		"ok":       {[]string{"a", "b"}, ""},
+		"too few":  {[]string{"a"}, "number of values must be >= 2"},
+		"too many": {[]string{"a", "b", "c"}, "number of values must be <= 2"},
+		"repeated": {[]string{"a", "a"}, `value "a" is repeated`},
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			args := argparse.ParsedArgs{
+				Parameters: map[string][]string{
+					"hosts": testValue.values,
+				},
+			}
+
+			err := args.Validate(suite.Context(), nil, conf.Parameters)
+
+			if testValue.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testValue.err)
+			}
+		})
+	}
+}
+
+func (suite *ParsedArgsTestSuite) TestValidatePositional() {
+	conf, err := config.Parse(strings.NewReader(`
+[[positional]]
+name = "count"
+type = "integer"
+required = true
+
+[[positional]]
+name = "rest"
+type = "enum"
+variadic = true
+spec = { choices = "a,b", max_count = "2" }`))
+	suite.NoError(err)
+
+	testTable := map[string]struct {
+		values []string
+		err    string
+	}{
+		"only required": {[]string{"1"}, ""},
+		"all":           {[]string{"1", "a", "b"}, ""},
+		"missing":       {[]string{}, "mandatory positional argument count"},
+		"invalid":       {[]string{"x"}, "invalid value for positional argument count"},
+		"invalid rest":  {[]string{"1", "c"}, "invalid value for positional argument rest"},
+		"too many rest": {[]string{"1", "a", "b", "a"}, "invalid values for positional argument rest"},
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			args := argparse.ParsedArgs{
+				Positional: testValue.values,
+			}
+
+			err := args.ValidatePositional(suite.Context(), conf.Positional)
+
+			if testValue.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testValue.err)
+			}
+		})
+	}
+}
+
+func (suite *ParsedArgsTestSuite) TestValidatePositionalTooMany() {
+	conf, err := config.Parse(strings.NewReader(`
+[[positional]]
+name = "x"
+type = "string"`))
+	suite.NoError(err)
+
+	args := argparse.ParsedArgs{
+		Positional: []string{"1", "2"},
+	}
+
+	suite.ErrorContains(
+		args.ValidatePositional(suite.Context(), conf.Positional),
+		"too many positional arguments")
+	suite.NoError(args.ValidatePositional(suite.Context(), nil))
+}
+
+func (suite *ParsedArgsTestSuite) TestNamedPositional() {
+	conf, err := config.Parse(strings.NewReader(`
+[[positional]]
+name = "x"
+type = "string"
+
+[[positional]]
+name = "y"
+type = "string"
+variadic = true`))
+	suite.NoError(err)
+
+	args := argparse.ParsedArgs{
+		Positional: []string{"1"},
+	}
+
+	suite.Equal(map[string][]string{
+		"x": {"1"},
+	}, args.NamedPositional(conf.Positional))
+
+	args.Positional = []string{"1", "2", "3"}
+
+	suite.Equal(map[string][]string{
+		"x": {"1"},
+		"y": {"2", "3"},
+	}, args.NamedPositional(conf.Positional))
+}
+
+func (suite *ParsedArgsTestSuite) TestMasked() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.token]
+type = "string"
+spec = { sensitive = "true" }
+
+[parameters.user]
+type = "string"
+
+[[positional]]
+name = "x"
+type = "string"
+
+[[positional]]
+name = "y"
+type = "string"
+spec = { sensitive = "true" }
+variadic = true`))
+	suite.NoError(err)
+
+	args := argparse.ParsedArgs{
+		Parameters: map[string][]string{
+			"token": {"t1", "t2"},
+			"user":  {"u"},
+		},
+		Flags:      map[string]bool{"f": true},
+		Positional: []string{"1", "2", "3"},
+	}
+
+	suite.Equal(argparse.ParsedArgs{
+		Parameters: map[string][]string{
+			"token": {argparse.MaskedValue, argparse.MaskedValue},
+			"user":  {"u"},
+		},
+		Flags:      map[string]bool{"f": true},
+		Positional: []string{"1", argparse.MaskedValue, argparse.MaskedValue},
+	}, args.Masked(conf.Parameters, conf.Positional))
+
+	suite.Equal([]string{"t1", "t2"}, args.Parameters["token"])
+	suite.Equal([]string{"1", "2", "3"}, args.Positional)
+}
+
 func (suite *ParsedArgsTestSuite) TestUnknownParameter() {
 	args := argparse.ParsedArgs{
 		Parameters: map[string][]string{
@@ -0,0 +1,206 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/9seconds/chore/internal/lock"
+)
+
+const (
+	SuffixStdout = ".stdout"
+	SuffixStderr = ".stderr"
+
+	FilePermission fs.FileMode = 0o600
+	DirPermission  fs.FileMode = 0o700
+)
+
+var ErrNoRuns = errors.New("no captured runs")
+
+type Capture struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	files []*os.File
+	lock  *lock.Lock
+}
+
+func (c *Capture) Close() error {
+	var errs []error
+
+	for _, file := range c.files {
+		errs = append(errs, file.Close())
+	}
+
+	if c.lock != nil {
+		errs = append(errs, c.lock.Release())
+	}
+
+	return errors.Join(errs...)
+}
+
+// New creates a pair of log files for a given run in dir. Stdout file
+// is locked until capture is closed: this is how other processes know
+// that the run is still going on. If chore dies, the lock is released
+// by the kernel.
+func New(dir, runID string, timestamps bool) (*Capture, error) {
+	if err := os.MkdirAll(dir, DirPermission); err != nil {
+		return nil, fmt.Errorf("cannot create log directory: %w", err)
+	}
+
+	capt := &Capture{}
+
+	// a file is locked before it is opened for writing so nobody can
+	// see it unlocked while the run is going on.
+	lck, err := lock.Acquire(context.Background(), StdoutPath(dir, runID), false)
+
+	switch {
+	case errors.Is(err, lock.ErrUnsupported):
+		log.Printf("cannot lock log file: %v", err)
+	case err != nil:
+		return nil, fmt.Errorf("cannot lock log file: %w", err)
+	default:
+		capt.lock = lck
+	}
+	writers := make([]io.Writer, 0, 2) //nolint: gomnd
+
+	for _, path := range []string{StdoutPath(dir, runID), StderrPath(dir, runID)} {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, FilePermission)
+		if err != nil {
+			capt.Close()
+
+			return nil, fmt.Errorf("cannot open log file %s: %w", path, err)
+		}
+
+		capt.files = append(capt.files, file)
+
+		var writer io.Writer = &fileWriter{
+			writer: file,
+			name:   path,
+		}
+
+		if timestamps {
+			writer = &timestampWriter{
+				writer: writer,
+				now:    time.Now,
+			}
+		}
+
+		writers = append(writers, writer)
+	}
+
+	capt.Stdout = writers[0]
+	capt.Stderr = writers[1]
+
+	return capt, nil
+}
+
+// IsRunning reports if a given run is still writing its output.
+func IsRunning(dir, runID string) (bool, error) {
+	return lock.IsLocked(StdoutPath(dir, runID))
+}
+
+func StdoutPath(dir, runID string) string {
+	return filepath.Join(dir, runID+SuffixStdout)
+}
+
+func StderrPath(dir, runID string) string {
+	return filepath.Join(dir, runID+SuffixStderr)
+}
+
+// ListRuns returns IDs of all captured runs. They are sorted from
+// oldest to newest: run IDs are k-sortable so lexicographical order
+// matches the order of creation.
+func ListRuns(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot read log directory: %w", err)
+	}
+
+	seen := map[string]bool{}
+	runs := []string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		runID := strings.TrimSuffix(strings.TrimSuffix(name, SuffixStdout), SuffixStderr)
+
+		if runID != name && !entry.IsDir() && !seen[runID] {
+			seen[runID] = true
+			runs = append(runs, runID)
+		}
+	}
+
+	sort.Strings(runs)
+
+	return runs, nil
+}
+
+// LatestRun returns ID of the most recent captured run.
+func LatestRun(dir string) (string, error) {
+	runs, err := ListRuns(dir)
+
+	switch {
+	case err != nil:
+		return "", err
+	case len(runs) == 0:
+		return "", ErrNoRuns
+	}
+
+	return runs[len(runs)-1], nil
+}
+
+// Rotate removes the oldest runs until there are no more than maxRuns
+// of them and their total size is no more than maxSize. The newest run
+// is always kept. Zero values mean no limit.
+func Rotate(dir string, maxRuns int, maxSize int64) error {
+	runs, err := ListRuns(dir)
+	if err != nil {
+		return err
+	}
+
+	sizes := make([]int64, len(runs))
+	totalSize := int64(0)
+
+	for idx, runID := range runs {
+		for _, path := range []string{StdoutPath(dir, runID), StderrPath(dir, runID)} {
+			if stat, err := os.Stat(path); err == nil {
+				sizes[idx] += stat.Size()
+			}
+		}
+
+		totalSize += sizes[idx]
+	}
+
+	for idx := 0; idx < len(runs)-1; idx++ {
+		left := len(runs) - idx
+		tooMany := maxRuns > 0 && left > maxRuns
+		tooBig := maxSize > 0 && totalSize > maxSize
+
+		if !tooMany && !tooBig {
+			break
+		}
+
+		for _, path := range []string{StdoutPath(dir, runs[idx]), StderrPath(dir, runs[idx])} {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("cannot remove %s: %w", path, err)
+			}
+		}
+
+		totalSize -= sizes[idx]
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package capture_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/9seconds/chore/internal/capture"
+	"github.com/stretchr/testify/suite"
+)
+
+type CaptureTestSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (suite *CaptureTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *CaptureTestSuite) MakeRun(runID, stdout, stderr string) {
+	capt, err := capture.New(suite.dir, runID, false)
+	suite.NoError(err)
+
+	fmt.Fprint(capt.Stdout, stdout)
+	fmt.Fprint(capt.Stderr, stderr)
+
+	suite.NoError(capt.Close())
+}
+
+func (suite *CaptureTestSuite) TestCapture() {
+	suite.MakeRun("run1", "out", "err")
+
+	content, err := os.ReadFile(capture.StdoutPath(suite.dir, "run1"))
+	suite.NoError(err)
+	suite.Equal("out", string(content))
+
+	content, err = os.ReadFile(capture.StderrPath(suite.dir, "run1"))
+	suite.NoError(err)
+	suite.Equal("err", string(content))
+
+	stat, err := os.Stat(capture.StdoutPath(suite.dir, "run1"))
+	suite.NoError(err)
+	suite.Equal(capture.FilePermission, stat.Mode().Perm())
+}
+
+func (suite *CaptureTestSuite) TestIsRunning() {
+	capt, err := capture.New(suite.dir, "run1", false)
+	suite.NoError(err)
+
+	running, err := capture.IsRunning(suite.dir, "run1")
+	suite.NoError(err)
+	suite.True(running)
+
+	suite.NoError(capt.Close())
+
+	running, err = capture.IsRunning(suite.dir, "run1")
+	suite.NoError(err)
+	suite.False(running)
+}
+
+func (suite *CaptureTestSuite) TestTimestamps() {
+	capt, err := capture.New(suite.dir, "run1", true)
+	suite.NoError(err)
+
+	fmt.Fprint(capt.Stdout, "line1\nli")
+	fmt.Fprint(capt.Stdout, "ne2\n\nline")
+
+	suite.NoError(capt.Close())
+
+	content, err := os.ReadFile(capture.StdoutPath(suite.dir, "run1"))
+	suite.NoError(err)
+
+	lines := strings.Split(string(content), "\n")
+	prefix := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\S+ `)
+
+	suite.Len(lines, 4)
+
+	for idx, expected := range []string{"line1", "line2", "", "line"} {
+		suite.Regexp(prefix, lines[idx])
+		suite.Equal(expected, prefix.ReplaceAllString(lines[idx], ""))
+	}
+}
+
+func (suite *CaptureTestSuite) TestListRuns() {
+	runs, err := capture.ListRuns(suite.dir + "/absent")
+	suite.NoError(err)
+	suite.Empty(runs)
+
+	_, err = capture.LatestRun(suite.dir)
+	suite.ErrorIs(err, capture.ErrNoRuns)
+
+	suite.MakeRun("run2", "", "")
+	suite.MakeRun("run1", "", "")
+	suite.NoError(os.WriteFile(suite.dir+"/garbage", nil, 0o600))
+
+	runs, err = capture.ListRuns(suite.dir)
+	suite.NoError(err)
+	suite.Equal([]string{"run1", "run2"}, runs)
+
+	latest, err := capture.LatestRun(suite.dir)
+	suite.NoError(err)
+	suite.Equal("run2", latest)
+}
+
+func (suite *CaptureTestSuite) TestRotateByCount() {
+	for _, v := range []string{"run1", "run2", "run3", "run4"} {
+		suite.MakeRun(v, "out", "err")
+	}
+
+	suite.NoError(capture.Rotate(suite.dir, 2, 0))
+
+	runs, err := capture.ListRuns(suite.dir)
+	suite.NoError(err)
+	suite.Equal([]string{"run3", "run4"}, runs)
+}
+
+func (suite *CaptureTestSuite) TestRotateBySize() {
+	for _, v := range []string{"run1", "run2", "run3", "run4"} {
+		suite.MakeRun(v, "12345", "12345")
+	}
+
+	suite.NoError(capture.Rotate(suite.dir, 0, 25))
+
+	runs, err := capture.ListRuns(suite.dir)
+	suite.NoError(err)
+	suite.Equal([]string{"run3", "run4"}, runs)
+}
+
+func (suite *CaptureTestSuite) TestRotateKeepsLatest() {
+	suite.MakeRun("run1", "12345", "12345")
+	suite.MakeRun("run2", "12345", "12345")
+
+	suite.NoError(capture.Rotate(suite.dir, 0, 1))
+
+	runs, err := capture.ListRuns(suite.dir)
+	suite.NoError(err)
+	suite.Equal([]string{"run2"}, runs)
+}
+
+func TestCapture(t *testing.T) {
+	suite.Run(t, &CaptureTestSuite{})
+}
@@ -0,0 +1,70 @@
+package capture
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+const FollowInterval = 250 * time.Millisecond
+
+// Follow copies captured output of a run into stdout and stderr. If
+// follow is true, it keeps polling log files for new data until the
+// run is finished or context is closed.
+func Follow(ctx context.Context, dir, runID string, stdout, stderr io.Writer, follow bool) error {
+	paths := []string{StdoutPath(dir, runID), StderrPath(dir, runID)}
+	writers := []io.Writer{stdout, stderr}
+	files := make([]*os.File, 0, len(paths))
+
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("cannot open log file: %w", err)
+		}
+
+		files = append(files, file)
+	}
+
+	ticker := time.NewTicker(FollowInterval)
+	defer ticker.Stop()
+
+	for {
+		// we need to check if we are done before copying otherwise
+		// it is possible to loose a tail of the file
+		done := !follow || !followIsRunning(dir, runID)
+
+		for idx, file := range files {
+			if _, err := io.Copy(writers[idx], file); err != nil {
+				return fmt.Errorf("cannot copy log file: %w", err)
+			}
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func followIsRunning(dir, runID string) bool {
+	running, err := IsRunning(dir, runID)
+	if err != nil {
+		log.Printf("cannot find out if run %s is finished: %v", runID, err)
+	}
+
+	return running
+}
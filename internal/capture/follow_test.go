@@ -0,0 +1,71 @@
+package capture_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/capture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollow(t *testing.T) {
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	capt, err := capture.New(dir, "run1", false)
+	assert.NoError(t, err)
+
+	fmt.Fprint(capt.Stdout, "hello")
+
+	go func() {
+		time.Sleep(2 * capture.FollowInterval)
+		fmt.Fprint(capt.Stdout, " world")
+		fmt.Fprint(capt.Stderr, "err")
+		capt.Close()
+	}()
+
+	assert.NoError(t, capture.Follow(context.Background(), dir, "run1", stdout, stderr, true))
+	assert.Equal(t, "hello world", stdout.String())
+	assert.Equal(t, "err", stderr.String())
+}
+
+func TestFollowOnce(t *testing.T) {
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+
+	capt, err := capture.New(dir, "run1", false)
+	assert.NoError(t, err)
+
+	defer capt.Close()
+
+	fmt.Fprint(capt.Stdout, "hello")
+
+	assert.NoError(t, capture.Follow(context.Background(), dir, "run1", stdout, &bytes.Buffer{}, false))
+	assert.Equal(t, "hello", stdout.String())
+}
+
+func TestFollowNotLocked(t *testing.T) {
+	dir := t.TempDir()
+	stdout := &bytes.Buffer{}
+
+	// a run whose chore has died without closing a capture
+	assert.NoError(t, os.WriteFile(capture.StdoutPath(dir, "run1"), []byte("hello"), 0o600))
+	assert.NoError(t, os.WriteFile(capture.StderrPath(dir, "run1"), nil, 0o600))
+
+	running, err := capture.IsRunning(dir, "run1")
+	assert.NoError(t, err)
+	assert.False(t, running)
+
+	assert.NoError(t, capture.Follow(context.Background(), dir, "run1", stdout, &bytes.Buffer{}, true))
+	assert.Equal(t, "hello", stdout.String())
+}
+
+func TestFollowAbsent(t *testing.T) {
+	err := capture.Follow(context.Background(), t.TempDir(), "run1", &bytes.Buffer{}, &bytes.Buffer{}, false)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
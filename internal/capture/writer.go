@@ -0,0 +1,74 @@
+package capture
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"time"
+)
+
+const TimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// fileWriter never fails. If we cannot write into a log file, this
+// should not break an output of the script to a terminal.
+type fileWriter struct {
+	writer io.Writer
+	name   string
+	failed bool
+}
+
+func (f *fileWriter) Write(p []byte) (int, error) {
+	if f.failed {
+		return len(p), nil
+	}
+
+	if _, err := f.writer.Write(p); err != nil {
+		log.Printf("cannot write to %s, stop capturing: %v", f.name, err)
+
+		f.failed = true
+	}
+
+	return len(p), nil
+}
+
+// timestampWriter prefixes each line with a timestamp of the moment
+// when a line has started.
+type timestampWriter struct {
+	writer      io.Writer
+	now         func() time.Time
+	inTheMiddle bool
+}
+
+func (t *timestampWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		if !t.inTheMiddle {
+			prefix := t.now().Format(TimestampFormat) + " "
+
+			if _, err := io.WriteString(t.writer, prefix); err != nil {
+				return written, err
+			}
+
+			t.inTheMiddle = true
+		}
+
+		chunk := p
+
+		if idx := bytes.IndexByte(p, '\n'); idx >= 0 {
+			chunk = p[:idx+1]
+			t.inTheMiddle = false
+		}
+
+		n, err := t.writer.Write(chunk)
+		written += n
+
+		if err != nil {
+			return written, err
+		}
+
+		p = p[len(chunk):]
+	}
+
+	return written, nil
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/9seconds/chore/internal/argparse"
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/history"
+	"github.com/9seconds/chore/internal/script"
+	"github.com/alessio/shellescape"
+	"github.com/spf13/cobra"
+)
+
+const HistoryDateFormat = "2006-01-02"
+
+func NewHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "history [flags] [namespace] [script]",
+		Aliases: []string{"hi"},
+		Short:   "Show history of script runs.",
+		Args: cobra.MatchAll(
+			cobra.MaximumNArgs(2), //nolint: gomnd
+			validators.ArgumentOptional(0, validators.Namespace(0)),
+			validators.ArgumentOptional(1, validators.Script(0, 1)),
+		),
+		Run:               base.Main(mainHistory),
+		ValidArgsFunction: completions.CompleteNamespaceScript,
+	}
+
+	flags := cmd.Flags()
+
+	flags.IntSliceP("exit-code", "e", nil, "show only runs finished with given exit codes")
+	flags.StringP("since", "s", "", "show runs started after this time (RFC3339, date or duration ago)")
+	flags.StringP("until", "u", "", "show runs started before this time (RFC3339, date or duration ago)")
+	flags.IntP("limit", "l", 0, "show only last N runs")
+	flags.BoolP("json", "j", false, "show runs as JSON")
+
+	return cmd
+}
+
+func mainHistory(cmd *cobra.Command, args []string) error {
+	filter, err := mainHistoryFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	scripts, err := mainHistoryScripts(args)
+	if err != nil {
+		return err
+	}
+
+	records := []history.Record{}
+
+	for _, scr := range scripts {
+		scriptRecords, err := history.Read(scr.HistoryPath(), filter)
+		if err != nil {
+			return fmt.Errorf("cannot read history of %s: %w", scr, err)
+		}
+
+		records = append(records, scriptRecords...)
+	}
+
+	history.Sort(records)
+
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(records); err != nil {
+			return fmt.Errorf("cannot encode records: %w", err)
+		}
+
+		return nil
+	}
+
+	mainHistoryTable(cmd, records)
+
+	return nil
+}
+
+func mainHistoryFilter(cmd *cobra.Command) (history.Filter, error) {
+	flags := cmd.Flags()
+	now := time.Now()
+	filter := history.Filter{}
+
+	filter.ExitCodes, _ = flags.GetIntSlice("exit-code")
+
+	if value, _ := flags.GetString("since"); value != "" {
+		since, err := parseTimeBoundary(value, now)
+		if err != nil {
+			return filter, fmt.Errorf("incorrect since value: %w", err)
+		}
+
+		filter.Since = since
+	}
+
+	if value, _ := flags.GetString("until"); value != "" {
+		until, err := parseTimeBoundary(value, now)
+		if err != nil {
+			return filter, fmt.Errorf("incorrect until value: %w", err)
+		}
+
+		filter.Until = until
+	}
+
+	return filter, nil
+}
+
+func mainHistoryScripts(args []string) ([]*script.Script, error) {
+	var namespaces []string
+
+	switch len(args) {
+	case 0:
+		names, err := script.ListNamespaces()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list namespaces: %w", err)
+		}
+
+		namespaces = names
+	case 1:
+		namespace, _ := script.ExtractRealNamespace(args[0])
+		namespaces = []string{namespace}
+	default:
+		namespace, _ := script.ExtractRealNamespace(args[0])
+
+		return []*script.Script{{Namespace: namespace, Executable: args[1]}}, nil
+	}
+
+	scripts := []*script.Script{}
+
+	for _, namespace := range namespaces {
+		names, err := script.ListScripts(namespace)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list scripts: %w", err)
+		}
+
+		for _, name := range names {
+			scripts = append(scripts, &script.Script{
+				Namespace:  namespace,
+				Executable: name,
+			})
+		}
+	}
+
+	return scripts, nil
+}
+
+func mainHistoryTable(cmd *cobra.Command, records []history.Record) {
+	if len(records) == 0 {
+		return
+	}
+
+	buf := &strings.Builder{}
+	writer := mainTabwriter(buf)
+
+	fmt.Fprintln(writer, "Started at\tScript\tRun ID\tExit code\tReal\tUser\tSys\tArguments")
+	fmt.Fprintln(writer, "╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴\t╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴\t╴╴╴╴\t╴╴╴\t╴╴╴╴╴╴╴╴╴")
+
+	for _, record := range records {
+		exitCode := strconv.Itoa(record.ExitCode)
+
+		if record.TimedOut {
+			exitCode += " (timeout)"
+		}
+
+		fmt.Fprintf(
+			writer,
+			"%s\t%s/%s\t%s\t%s\t%v\t%v\t%v\t%s\n",
+			record.StartedAt.Local().Format(time.RFC3339),
+			record.Namespace,
+			record.Script,
+			record.ID,
+			exitCode,
+			record.ElapsedTime.Round(time.Millisecond),
+			record.UserTime.Round(time.Millisecond),
+			record.SystemTime.Round(time.Millisecond),
+			mainHistoryArguments(record))
+	}
+
+	writer.Flush()
+
+	cmd.Print(buf.String())
+}
+
+func mainHistoryArguments(record history.Record) string {
+	parsed := argparse.ParsedArgs{
+		Parameters: record.Parameters,
+		Flags:      record.Flags,
+	}
+
+	chunks := parsed.ToSelfStringChunks()
+
+	if len(record.Positional) > 0 {
+		chunks = append(chunks, argparse.PositionalDelimiter)
+		chunks = append(chunks, record.Positional...)
+	}
+
+	return shellescape.QuoteCommand(chunks)
+}
+
+// parseTimeBoundary parses a time in RFC3339 format, a date or a
+// duration. Duration is treated as 'ago' from now.
+func parseTimeBoundary(value string, now time.Time) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	if parsed, err := time.ParseInLocation(HistoryDateFormat, value, time.Local); err == nil {
+		return parsed, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %s as a time, date or duration", value)
+	}
+
+	return now.Add(-duration), nil
+}
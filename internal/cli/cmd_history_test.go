@@ -0,0 +1,119 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/cli"
+	"github.com/9seconds/chore/internal/history"
+	"github.com/9seconds/chore/internal/paths"
+	"github.com/stretchr/testify/suite"
+)
+
+type CmdHistoryTestSuite struct {
+	CmdTestSuite
+}
+
+func (suite *CmdHistoryTestSuite) SetupTest() {
+	suite.CmdTestSuite.Setup("history", cli.NewHistory)
+
+	suite.EnsureScript("ns", "s", "echo 1")
+	suite.EnsureScript("ns", "s2", "echo 1")
+	suite.EnsureScript("xx", "s", "echo 1")
+
+	now := time.Now()
+	records := map[string]history.Record{
+		paths.StateNamespaceScriptHistory("ns", "s"): {
+			Namespace: "ns",
+			Script:    "s",
+			ID:        "run1",
+			StartedAt: now.Add(-2 * time.Hour),
+		},
+		paths.StateNamespaceScriptHistory("ns", "s2"): {
+			Namespace: "ns",
+			Script:    "s2",
+			ID:        "run2",
+			ExitCode:  3,
+			StartedAt: now.Add(-time.Hour),
+			Flags:     map[string]bool{"x": true},
+		},
+		paths.StateNamespaceScriptHistory("xx", "s"): {
+			Namespace:  "xx",
+			Script:     "s",
+			ID:         "run3",
+			StartedAt:  now,
+			Positional: []string{"a b"},
+		},
+	}
+
+	for path, record := range records {
+		suite.EnsureDir(paths.StateNamespaceScript(record.Namespace, record.Script))
+		suite.NoError(history.Append(path, record))
+	}
+}
+
+func (suite *CmdHistoryTestSuite) ReadJSON(args ...string) []string {
+	ctx, err := suite.ExecuteCommand(append([]string{"--json"}, args...)...)
+	suite.NoError(err)
+	suite.Empty(ctx.StderrLines())
+
+	records := []history.Record{}
+	suite.NoError(json.Unmarshal(ctx.Stdout.Bytes(), &records))
+
+	ids := make([]string, 0, len(records))
+
+	for _, v := range records {
+		ids = append(ids, v.ID)
+	}
+
+	return ids
+}
+
+func (suite *CmdHistoryTestSuite) TestAll() {
+	suite.Equal([]string{"run1", "run2", "run3"}, suite.ReadJSON())
+}
+
+func (suite *CmdHistoryTestSuite) TestNamespace() {
+	suite.Equal([]string{"run1", "run2"}, suite.ReadJSON("ns"))
+}
+
+func (suite *CmdHistoryTestSuite) TestScript() {
+	suite.Equal([]string{"run3"}, suite.ReadJSON("xx", "s"))
+}
+
+func (suite *CmdHistoryTestSuite) TestExitCode() {
+	suite.Equal([]string{"run2"}, suite.ReadJSON("-e", "3"))
+}
+
+func (suite *CmdHistoryTestSuite) TestTimeRange() {
+	suite.Equal([]string{"run2"}, suite.ReadJSON("--since", "90m", "--until", "30m"))
+}
+
+func (suite *CmdHistoryTestSuite) TestLimit() {
+	suite.Equal([]string{"run2", "run3"}, suite.ReadJSON("-l", "2"))
+}
+
+func (suite *CmdHistoryTestSuite) TestIncorrectSince() {
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("--since", "xxx")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "incorrect since value")
+}
+
+func (suite *CmdHistoryTestSuite) TestTable() {
+	ctx, err := suite.ExecuteCommand()
+	suite.NoError(err)
+
+	lines := ctx.StdoutLines()
+
+	suite.Len(lines, 5)
+	suite.Contains(lines[2], "ns/s")
+	suite.Contains(lines[3], "+x")
+	suite.Contains(lines[4], "-- 'a b'")
+}
+
+func TestCmdHistory(t *testing.T) {
+	suite.Run(t, &CmdHistoryTestSuite{})
+}
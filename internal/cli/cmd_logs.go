@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+
+	"github.com/9seconds/chore/internal/capture"
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/script"
+	"github.com/spf13/cobra"
+)
+
+var ErrRunIsNotCaptured = errors.New("output of this run was not captured")
+
+func NewLogs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [flags] namespace script [run-id]",
+		Short: "Show captured output of script runs.",
+		Args: cobra.MatchAll(
+			cobra.RangeArgs(2, 3), //nolint: gomnd
+			validators.Script(0, 1),
+		),
+		Run:               base.Main(mainLogs),
+		ValidArgsFunction: completeLogs,
+	}
+
+	flags := cmd.Flags()
+
+	flags.BoolP("follow", "f", false, "follow output of the run until it is finished")
+	flags.BoolP("list", "l", false, "list IDs of captured runs")
+
+	return cmd
+}
+
+func mainLogs(cmd *cobra.Command, args []string) error {
+	namespace, _ := script.ExtractRealNamespace(args[0])
+	scr := &script.Script{
+		Namespace:  namespace,
+		Executable: args[1],
+	}
+
+	if listRuns, _ := cmd.Flags().GetBool("list"); listRuns {
+		runs, err := capture.ListRuns(scr.LogsPath())
+		if err != nil {
+			return fmt.Errorf("cannot list captured runs: %w", err)
+		}
+
+		for _, v := range runs {
+			cmd.Println(v)
+		}
+
+		return nil
+	}
+
+	runID := ""
+
+	if len(args) > 2 { //nolint: gomnd
+		runID = args[2]
+	} else {
+		latest, err := capture.LatestRun(scr.LogsPath())
+		if err != nil {
+			return fmt.Errorf("cannot find out the latest run: %w", err)
+		}
+
+		runID = latest
+	}
+
+	follow, _ := cmd.Flags().GetBool("follow")
+
+	err := capture.Follow(cmd.Context(), scr.LogsPath(), runID, cmd.OutOrStdout(), cmd.ErrOrStderr(), follow)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrRunIsNotCaptured
+	}
+
+	return err
+}
+
+func completeLogs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) < 2 { //nolint: gomnd
+		return completions.CompleteNamespaceScript(cmd, args, toComplete)
+	}
+
+	if len(args) > 2 { //nolint: gomnd
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	namespace, _ := script.ExtractRealNamespace(args[0])
+	scr := &script.Script{
+		Namespace:  namespace,
+		Executable: args[1],
+	}
+
+	runs, err := capture.ListRuns(scr.LogsPath())
+	if err != nil {
+		log.Printf("cannot list captured runs: %v", err)
+
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return runs, cobra.ShellCompDirectiveNoFileComp
+}
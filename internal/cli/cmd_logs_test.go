@@ -0,0 +1,89 @@
+package cli_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/capture"
+	"github.com/9seconds/chore/internal/cli"
+	"github.com/9seconds/chore/internal/paths"
+	"github.com/stretchr/testify/suite"
+)
+
+type CmdLogsTestSuite struct {
+	CmdTestSuite
+}
+
+func (suite *CmdLogsTestSuite) SetupTest() {
+	suite.CmdTestSuite.Setup("logs", cli.NewLogs)
+
+	suite.EnsureScript("ns", "s", "echo 1")
+
+	logsPath := paths.StateNamespaceScriptLogs("ns", "s")
+
+	for _, runID := range []string{"run1", "run2"} {
+		capt, err := capture.New(logsPath, runID, false)
+		suite.NoError(err)
+
+		fmt.Fprintln(capt.Stdout, "stdout of "+runID)
+		fmt.Fprintln(capt.Stderr, "stderr of "+runID)
+
+		suite.NoError(capt.Close())
+	}
+}
+
+func (suite *CmdLogsTestSuite) TestList() {
+	ctx, err := suite.ExecuteCommand("--list", "ns", "s")
+	suite.NoError(err)
+	suite.Equal([]string{"run1", "run2"}, ctx.StdoutLines())
+}
+
+func (suite *CmdLogsTestSuite) TestLatest() {
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Equal([]string{"stdout of run2"}, ctx.StdoutLines())
+	suite.Equal([]string{"stderr of run2"}, ctx.StderrLines())
+}
+
+func (suite *CmdLogsTestSuite) TestRun() {
+	ctx, err := suite.ExecuteCommand("ns", "s", "run1")
+	suite.NoError(err)
+	suite.Equal([]string{"stdout of run1"}, ctx.StdoutLines())
+	suite.Equal([]string{"stderr of run1"}, ctx.StderrLines())
+}
+
+func (suite *CmdLogsTestSuite) TestFollowFinishedRun() {
+	ctx, err := suite.ExecuteCommand("-f", "ns", "s", "run1")
+	suite.NoError(err)
+	suite.Equal([]string{"stdout of run1"}, ctx.StdoutLines())
+}
+
+func (suite *CmdLogsTestSuite) TestFollowRunningRun() {
+	capt, err := capture.New(paths.StateNamespaceScriptLogs("ns", "s"), "run3", false)
+	suite.NoError(err)
+
+	fmt.Fprintln(capt.Stdout, "line1")
+
+	go func() {
+		time.Sleep(2 * capture.FollowInterval)
+		fmt.Fprintln(capt.Stdout, "line2")
+		capt.Close()
+	}()
+
+	ctx, err := suite.ExecuteCommand("-f", "ns", "s", "run3")
+	suite.NoError(err)
+	suite.Equal([]string{"line1", "line2"}, ctx.StdoutLines())
+}
+
+func (suite *CmdLogsTestSuite) TestUnknownRun() {
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s", "run3")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "output of this run was not captured")
+}
+
+func TestCmdLogs(t *testing.T) {
+	suite.Run(t, &CmdLogsTestSuite{})
+}
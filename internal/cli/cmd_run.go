@@ -1,23 +1,62 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/9seconds/chore/internal/argparse"
+	"github.com/9seconds/chore/internal/binutils"
+	"github.com/9seconds/chore/internal/capture"
 	"github.com/9seconds/chore/internal/cli/base"
 	"github.com/9seconds/chore/internal/cli/validators"
 	"github.com/9seconds/chore/internal/commands"
 	"github.com/9seconds/chore/internal/config"
+	"github.com/9seconds/chore/internal/dotenv"
 	"github.com/9seconds/chore/internal/env"
+	"github.com/9seconds/chore/internal/history"
+	"github.com/9seconds/chore/internal/lock"
+	"github.com/9seconds/chore/internal/memoize"
+	"github.com/9seconds/chore/internal/paths"
+	"github.com/9seconds/chore/internal/prompt"
 	"github.com/9seconds/chore/internal/script"
+	scriptconfig "github.com/9seconds/chore/internal/script/config"
+	"github.com/9seconds/chore/internal/vault"
 	"github.com/spf13/cobra"
 )
 
+// ExitCodeTimeout is an exit code chore uses if script was terminated
+// because of execution timeout. It is the same code that coreutils
+// timeout uses.
+const ExitCodeTimeout = 124
+
+// ExitCodeSignalBase is added to a signal number to get an exit code of
+// a script terminated by this signal. It is the same code that shells
+// use.
+const ExitCodeSignalBase = 128
+
+const (
+	// SecretFilesDirName is a name of the directory in the script
+	// temporary directory where secret files are written to.
+	SecretFilesDirName = "secrets"
+
+	SecretFilesDirPermission = 0o700
+	SecretFilePermission     = 0o600
+)
+
 func NewRun() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "run namespace script [options] [--] [args]",
+		Use:     "run [flags] namespace script [options] [--] [args]",
 		Aliases: []string{"r"},
 		Short:   "Run chore script",
 		Args: cobra.MatchAll(
@@ -27,20 +66,29 @@ func NewRun() *cobra.Command {
 		Run:                   base.Main(mainRun),
 		ValidArgsFunction:     completeRun,
 		DisableFlagsInUseLine: true,
-		DisableFlagParsing:    true,
 	}
 
-	// workaround to get rid of -h/--help flag
-	cmd.InitDefaultHelpFlag()
+	flags := cmd.Flags()
 
-	if err := cmd.Flags().MarkHidden("help"); err != nil {
-		panic(err.Error())
-	}
+	// flags are accepted only before a namespace. Everything after
+	// belongs to the script.
+	flags.SetInterspersed(false)
+
+	flags.Bool("wait", true, "wait for a lock if the script is already running")
+	flags.Bool("no-wait", false, "fail immediately if the script is already running")
+	flags.Duration("wait-timeout", 0, "how long to wait for a lock. 0 means forever")
+	flags.Bool("refresh", false, "ignore memoized result and run the script")
+	flags.Bool("time", false, "print resource usage of the script to stderr when it exits")
+	flags.Bool("explain", false, "show how the script would be executed without running it")
+	flags.BoolP("json", "j", false, "show explanation as JSON")
+	flags.Bool("no-input", false, "never ask for missing parameters and flags")
+
+	cmd.MarkFlagsMutuallyExclusive("wait", "no-wait")
 
 	return cmd
 }
 
-func mainRun(cmd *cobra.Command, args []string) error {
+func mainRun(cmd *cobra.Command, args []string) error { //nolint: cyclop
 	ctx := cmd.Context()
 	namespace, _ := script.ExtractRealNamespace(args[0])
 
@@ -58,39 +106,569 @@ func mainRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot initialize script directories: %w", err)
 	}
 
-	parsedArgs, err := argparse.Parse(args[2:])
+	parsedArgs, err := argparse.Parse(args[2:], scr.Config.Parameters)
 	if err != nil {
 		return fmt.Errorf("cannot parse arguments: %w", err)
 	}
 
+	parsedArgs.ApplyDefaults(scr.Config.Flags, scr.Config.Parameters)
+
+	if noInput, _ := cmd.Flags().GetBool("no-input"); !noInput && prompt.IsInteractive(cmd.InOrStdin()) {
+		if err := mainRunPrompt(cmd, scr, parsedArgs); err != nil {
+			return fmt.Errorf("cannot ask for missing arguments: %w", err)
+		}
+	}
+
 	if err := parsedArgs.Validate(ctx, scr.Config.Flags, scr.Config.Parameters); err != nil {
 		return fmt.Errorf("cannot validate arguments: %w", err)
 	}
 
+	if err := parsedArgs.ValidatePositional(ctx, scr.Config.Positional); err != nil {
+		return fmt.Errorf("cannot validate arguments: %w", err)
+	}
+
+	explain, _ := cmd.Flags().GetBool("explain")
+
+	secrets, err := mainRunSecrets(cmd, conf, scr, !explain)
+	if err != nil {
+		return err
+	}
+
+	if explain {
+		return mainRunExplain(cmd, conf, scr, parsedArgs, secrets)
+	}
+
+	lck, err := mainRunLock(cmd, scr, parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	if lck != nil {
+		defer func() {
+			if err := lck.Release(); err != nil {
+				log.Printf("cannot release lock: %v", err)
+			}
+		}()
+	}
+
+	if result, ok := mainRunLoadMemoized(cmd, scr, parsedArgs); ok {
+		if err := result.Replay(os.Stdout, os.Stderr); err != nil {
+			return fmt.Errorf("cannot replay memoized result: %w", err)
+		}
+
+		return base.ErrExit{
+			Code: result.ExitCode,
+		}
+	}
+
 	confEnviron := conf.Environ(namespace)
-	for _, v := range confEnviron {
-		log.Printf("config env: %s", v)
+	chainID := ""
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			scr.ID = binutils.NewID()
+		}
+
+		vars, _, err := mainRunEnviron(ctx, confEnviron, secrets, scr, parsedArgs, chainID, attempt)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range vars {
+			log.Printf("env (%s): %s", v.Source, v.Masked())
+		}
+
+		environ := env.FromVariables(vars)
+
+		// all attempts belong to the same chain
+		if chainID == "" {
+			chainID, _ = env.Lookup(environ, env.IDChainRun)
+		}
+
+		result, err := mainRunAttempt(ctx, scr, parsedArgs, environ, attempt)
+		if err != nil {
+			return err
+		}
+
+		if showTime, _ := cmd.Flags().GetBool("time"); showTime {
+			mainRunPrintTime(cmd, scr, result)
+		}
+
+		exitCode := mainRunExitCode(result)
+
+		if result.TimedOut {
+			log.Printf("command %s was terminated by timeout %v", scr, scr.Config.Timeout)
+			cmd.PrintErrf("%s was terminated: timeout %v has fired\n", scr, scr.Config.Timeout)
+		} else if result.Signal != nil {
+			log.Printf("command %s was terminated by %v", scr, result.Signal)
+
+			if reason := scr.Config.Limits.ExplainSignal(result.Signal); reason != "" && !result.Stopped {
+				cmd.PrintErrf("%s was terminated by %v: %s\n", scr, result.Signal, reason)
+			}
+		}
+
+		if mainRunIsInterrupted(result) || !scr.Config.Retry.ShouldRetry(attempt, exitCode) {
+			return base.ErrExit{
+				Code: exitCode,
+			}
+		}
+
+		delay := scr.Config.Retry.NextDelay(attempt)
+
+		log.Printf("attempt %d has failed with exit code %d, retry in %v", attempt, exitCode, delay)
+
+		select {
+		case <-ctx.Done():
+			return base.ErrExit{
+				Code: exitCode,
+			}
+		case <-time.After(delay):
+		}
+	}
+}
+
+// mainRunExitCode returns an exit code chore reports for a finished
+// attempt: ExitCodeTimeout on timeout, 128+signo if script was killed
+// by a signal and its own exit code otherwise.
+func mainRunExitCode(result commands.ExecutionResult) int {
+	if result.TimedOut {
+		return ExitCodeTimeout
 	}
 
-	scriptEnviron := scr.Environ(ctx, parsedArgs)
-	for _, v := range scriptEnviron {
-		log.Printf("script env: %s", v)
+	if sig, ok := result.Signal.(syscall.Signal); ok {
+		return ExitCodeSignalBase + int(sig)
 	}
 
-	environ := env.Environ()
-	environ = append(environ, confEnviron...)
-	environ = append(environ, scriptEnviron...)
+	return result.ExitCode
+}
+
+// mainRunIsInterrupted tells if an attempt was interrupted by a user
+// or chore was asked to stop. Such attempts are never retried. Script
+// usually owns a terminal, so Ctrl-C reaches only the script, not
+// chore. Timeouts are not interruptions: they are retried if
+// on_exit_codes allows that.
+func mainRunIsInterrupted(result commands.ExecutionResult) bool {
+	if result.TimedOut {
+		return false
+	}
+
+	switch result.Signal {
+	case syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT:
+		return true
+	}
+
+	return result.Stopped
+}
+
+// mainRunPrompt asks for required flags and parameters which were not
+// provided in a command line.
+func mainRunPrompt(cmd *cobra.Command, scr *script.Script, args argparse.ParsedArgs) error {
+	ctx := cmd.Context()
+	prmpt := prompt.New(cmd.InOrStdin(), cmd.ErrOrStderr())
+
+	for _, name := range args.MissingFlags(scr.Config.Flags) {
+		cmd.PrintErrf("Flag %s: %s\n", name, scr.Config.Flags[name].Description())
+
+		value, err := prmpt.Confirm("Enable " + name + "?")
+		if err != nil {
+			return fmt.Errorf("cannot read flag %s: %w", name, err)
+		}
+
+		args.Flags[name] = value
+	}
+
+	for _, name := range args.MissingParameters(scr.Config.Parameters) {
+		param := scr.Config.Parameters[name]
+
+		cmd.PrintErrf("Parameter %s (%s): %s\n", name, param.Type(), param.Description())
+
+		if spec := param.Specification(); len(spec) > 0 {
+			cmd.PrintErrf("Spec: %s\n", mainShowParameterSpec(spec))
+		}
+
+		var (
+			value string
+			err   error
+		)
+
+		if withChoices, ok := param.(scriptconfig.ParameterWithChoices); ok {
+			value, err = prmpt.Choose(name, withChoices.Choices())
+		} else {
+			value, err = prmpt.Ask(name, param.Sensitive(), func(value string) error {
+				_, err := mainRunPromptValues(ctx, param, value)
+
+				return err
+			})
+		}
+
+		if err != nil {
+			return fmt.Errorf("cannot read parameter %s: %w", name, err)
+		}
+
+		args.Parameters[name], err = mainRunPromptValues(ctx, param, value)
+		if err != nil {
+			return fmt.Errorf("invalid values for parameter %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// mainRunPromptValues treats an answer as a command line value of
+// a parameter and validates it. This way an incorrect answer can be
+// asked again instead of failing later.
+func mainRunPromptValues(ctx context.Context, param scriptconfig.Parameter, answer string) ([]string, error) {
+	values, err := argparse.ParseValue(param, answer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := param.List().Validate(values); err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		if err := param.Validate(ctx, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// mainRunSecrets reads values of script secrets from the namespace
+// vault. Secret files are written into the script temporary directory
+// if writeFiles is set; otherwise only their paths are returned.
+func mainRunSecrets(
+	cmd *cobra.Command,
+	conf config.Config,
+	scr *script.Script,
+	writeFiles bool,
+) ([]env.Variable, error) {
+	if len(scr.Config.Secrets) == 0 && len(scr.Config.SecretFiles) == 0 {
+		return nil, nil
+	}
+
+	password, err := conf.GetVaultPassword(cmd.Context(), scr.Namespace, cmd.InOrStdin(), cmd.ErrOrStderr())
+	if err != nil {
+		return nil, err
+	}
+
+	vlt, err := vault.OpenFile(paths.ConfigNamespaceScriptVault(scr.Namespace), password)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open vault: %w", err)
+	}
+
+	secrets := []env.Variable{}
+
+	for _, name := range mainRunSortedKeys(scr.Config.Secrets) {
+		key := scr.Config.Secrets[name]
+
+		value, metadata, err := mainRunSecret(cmd, vlt, scr, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if metadata.Binary {
+			return nil, fmt.Errorf("secret %s is binary, please use secret_files", key)
+		}
+
+		secrets = append(secrets, env.Variable{
+			Name:   name,
+			Value:  value,
+			Source: env.SourceSecret,
+		})
+	}
+
+	secretsDir := filepath.Join(scr.TempPath(), SecretFilesDirName)
+
+	if writeFiles && len(scr.Config.SecretFiles) > 0 {
+		if err := os.Mkdir(secretsDir, SecretFilesDirPermission); err != nil {
+			return nil, fmt.Errorf("cannot create a directory for secret files: %w", err)
+		}
+	}
+
+	for _, name := range mainRunSortedKeys(scr.Config.SecretFiles) {
+		key := scr.Config.SecretFiles[name]
+
+		value, metadata, err := mainRunSecret(cmd, vlt, scr, key)
+		if err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(secretsDir, name)
+
+		if writeFiles {
+			data, err := metadata.Decode(value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode secret %s: %w", key, err)
+			}
+
+			if err := os.WriteFile(path, data, SecretFilePermission); err != nil {
+				return nil, fmt.Errorf("cannot write secret file for %s: %w", key, err)
+			}
+		}
+
+		secrets = append(secrets, env.Variable{
+			Name:   name,
+			Value:  path,
+			Source: env.SourceSecretFile,
+		})
+	}
+
+	return secrets, nil
+}
+
+// mainRunSecret reads a secret which is used by the script. It checks
+// that script is allowed to read it and if it has expired.
+func mainRunSecret(
+	cmd *cobra.Command,
+	vlt vault.Vault,
+	scr *script.Script,
+	key string,
+) (string, vault.Metadata, error) {
+	value, ok := vlt.Get(key)
+	if !ok {
+		return "", vault.Metadata{}, fmt.Errorf("secret %s is not found in vault", key)
+	}
+
+	metadata, _ := vlt.Metadata(key)
+
+	if !metadata.AllowsScript(scr.Executable) {
+		return "", metadata, fmt.Errorf("script %s is not allowed to read secret %s", scr.Executable, key)
+	}
+
+	if metadata.IsExpired(time.Now()) {
+		expires := metadata.Expires.Local().Format(time.RFC3339)
+
+		switch scr.Config.ExpiredSecrets {
+		case scriptconfig.ExpiredSecretsModeFail:
+			return "", metadata, fmt.Errorf("secret %s has expired on %s", key, expires)
+		case scriptconfig.ExpiredSecretsModeWarn:
+			cmd.PrintErrf("Secret %s has expired on %s\n", key, expires)
+		case scriptconfig.ExpiredSecretsModeIgnore:
+		}
+	}
+
+	return value, metadata, nil
+}
+
+func mainRunSortedKeys(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// mainRunEnviron builds a complete environment of the script run.
+// Variables are ordered by precedence: if a name is defined several
+// times, the last value wins.
+func mainRunEnviron(
+	ctx context.Context,
+	confEnviron []string,
+	secrets []env.Variable,
+	scr *script.Script,
+	args argparse.ParsedArgs,
+	chainID string,
+	attempt int,
+) ([]env.Variable, []env.Skip, error) {
+	vars := env.MakeVariables(scr.Config.Environment.Filter(env.Environ()), env.SourceParent)
+	vars = append(vars, env.MakeVariables(confEnviron, env.SourceConfig)...)
+
+	for _, path := range scr.DotenvPaths() {
+		environ := env.FromVariables(vars)
+
+		values, err := dotenv.Load(path, func(name string) (string, bool) {
+			return env.Lookup(environ, name)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot load dotenv file %s: %w", path, err)
+		}
+
+		vars = append(vars, env.MakeVariables(values, env.SourceDotenv(path))...)
+	}
 
+	vars = append(vars, secrets...)
+
+	scriptVars, skips := scr.EnvironVariables(ctx, args)
+	vars = append(vars, scriptVars...)
+
+	runEnviron := []string{
+		env.MakeValue(env.RetryAttempt, strconv.Itoa(attempt)),
+	}
+
+	if chainID != "" {
+		runEnviron = append(runEnviron, env.MakeValue(env.IDChainRun, chainID))
+	}
+
+	vars = append(vars, env.MakeVariables(runEnviron, env.SourceRun)...)
+
+	return vars, skips, nil
+}
+
+type runExplainedVariable struct {
+	env.Variable
+
+	Overrides []string `json:"overrides,omitempty"`
+}
+
+type runExplanation struct {
+	Argv        []string               `json:"argv"`
+	WorkingDir  string                 `json:"working_dir"`
+	Environment []runExplainedVariable `json:"environment"`
+	Skipped     []env.Skip             `json:"skipped"`
+}
+
+func mainRunExplain(
+	cmd *cobra.Command,
+	conf config.Config,
+	scr *script.Script,
+	args argparse.ParsedArgs,
+	secrets []env.Variable,
+) error {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot get working directory: %w", err)
+	}
+
+	vars, skips, err := mainRunEnviron(
+		cmd.Context(),
+		conf.Environ(scr.Namespace),
+		secrets,
+		scr,
+		args,
+		"",
+		1)
+	if err != nil {
+		return err
+	}
+
+	masked := args.Masked(scr.Config.Parameters, scr.Config.Positional)
+	explanation := runExplanation{
+		Argv:        append([]string{scr.Path()}, masked.Positional...),
+		WorkingDir:  workingDir,
+		Environment: []runExplainedVariable{},
+		Skipped:     skips,
+	}
+	indexes := map[string]int{}
+
+	for _, v := range vars {
+		v := v.Masked()
+
+		idx, ok := indexes[v.Name]
+		if !ok {
+			indexes[v.Name] = len(explanation.Environment)
+			explanation.Environment = append(explanation.Environment, runExplainedVariable{
+				Variable: v,
+			})
+
+			continue
+		}
+
+		explained := &explanation.Environment[idx]
+		explained.Overrides = append(explained.Overrides, explained.Source)
+		explained.Variable = v
+	}
+
+	sort.Slice(explanation.Environment, func(i, j int) bool {
+		return explanation.Environment[i].Name < explanation.Environment[j].Name
+	})
+	sort.SliceStable(explanation.Skipped, func(i, j int) bool {
+		return explanation.Skipped[i].Generator < explanation.Skipped[j].Generator
+	})
+
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(explanation); err != nil {
+			return fmt.Errorf("cannot encode explanation: %w", err)
+		}
+
+		return nil
+	}
+
+	buf := &strings.Builder{}
+	writer := mainTabwriter(buf)
+
+	fmt.Fprintln(writer, "Argv:")
+
+	for _, arg := range explanation.Argv {
+		fmt.Fprintf(writer, "\t%q\n", arg)
+	}
+
+	fmt.Fprintf(writer, "Working directory:\t%s\n", explanation.WorkingDir)
+	fmt.Fprintln(writer, "Environment:")
+
+	for _, v := range explanation.Environment {
+		source := v.Source
+		if len(v.Overrides) > 0 {
+			source += " (overrides " + strings.Join(v.Overrides, ", ") + ")"
+		}
+
+		fmt.Fprintf(writer, "\t%s\t%q\t%s\n", v.Name, v.Value, source)
+	}
+
+	fmt.Fprintln(writer, "Skipped:")
+
+	for _, skip := range explanation.Skipped {
+		fmt.Fprintf(writer, "\t%s\t%s\n", skip.Generator, skip.Reason)
+	}
+
+	writer.Flush()
+
+	cmd.Print(buf.String())
+
+	return nil
+}
+
+func mainRunAttempt(
+	ctx context.Context,
+	scr *script.Script,
+	args argparse.ParsedArgs,
+	environ []string,
+	attempt int,
+) (commands.ExecutionResult, error) {
+	stdout, stderr, closeCapture, err := mainRunCaptureOutput(scr)
+	if err != nil {
+		return commands.ExecutionResult{}, fmt.Errorf("cannot capture output: %w", err)
+	}
+
+	defer closeCapture()
+
+	recorder := mainRunMemoizeRecorder(scr, args)
+	if recorder != nil {
+		stdout = io.MultiWriter(stdout, recorder.Stdout)
+		stderr = io.MultiWriter(stderr, recorder.Stderr)
+	}
+
+	startedAt := time.Now()
 	runCmd := commands.New(
 		scr.Path(),
-		parsedArgs.Positional,
+		args.Positional,
 		environ,
 		os.Stdin,
-		os.Stdout,
-		os.Stderr)
+		stdout,
+		stderr,
+		commands.ExecutionPolicy{
+			Timeout:         scr.Config.Timeout,
+			StopSignal:      scr.Config.StopSignal,
+			StopGracePeriod: scr.Config.StopGracePeriod,
+			Limits:          scr.Config.Limits,
+		})
 
 	if err := runCmd.Start(ctx); err != nil {
-		return fmt.Errorf("cannot start command: %w", err)
+		if recorder != nil {
+			recorder.Discard() //nolint: errcheck
+		}
+
+		return commands.ExecutionResult{}, fmt.Errorf("cannot start command: %w", err)
 	}
 
 	log.Printf("command %s has started as %d", scr, runCmd.Pid())
@@ -105,7 +683,195 @@ func mainRun(cmd *cobra.Command, args []string) error {
 		result.SystemTime,
 		result.ElapsedTime)
 
-	return base.ErrExit{
-		Code: result.ExitCode,
+	mainRunAppendHistory(scr, args, environ, attempt, startedAt, result)
+
+	if recorder != nil {
+		mainRunMemoizeResult(recorder, result)
+	}
+
+	return result, nil
+}
+
+func mainRunPrintTime(cmd *cobra.Command, scr *script.Script, result commands.ExecutionResult) {
+	buf := &strings.Builder{}
+	signal := "-"
+
+	if result.Signal != nil {
+		signal = result.Signal.String()
+	}
+
+	fmt.Fprintf(buf, "\tCommand being timed: %q\n", scr.String())
+	fmt.Fprintf(buf, "\tUser time (seconds): %.2f\n", result.UserTime.Seconds())
+	fmt.Fprintf(buf, "\tSystem time (seconds): %.2f\n", result.SystemTime.Seconds())
+	fmt.Fprintf(buf, "\tElapsed (wall clock) time: %v\n", result.ElapsedTime.Round(time.Millisecond))
+	fmt.Fprintf(buf, "\tMaximum resident set size (kbytes): %d\n", result.MaxRSS/1024) //nolint: gomnd
+	fmt.Fprintf(buf, "\tMajor (requiring I/O) page faults: %d\n", result.MajorPageFaults)
+	fmt.Fprintf(buf, "\tMinor (reclaiming a frame) page faults: %d\n", result.MinorPageFaults)
+	fmt.Fprintf(buf, "\tVoluntary context switches: %d\n", result.VoluntaryContextSwitches)
+	fmt.Fprintf(buf, "\tInvoluntary context switches: %d\n", result.InvoluntaryContextSwitches)
+	fmt.Fprintf(buf, "\tFile system inputs: %d\n", result.BlockInput)
+	fmt.Fprintf(buf, "\tFile system outputs: %d\n", result.BlockOutput)
+	fmt.Fprintf(buf, "\tTerminating signal: %s\n", signal)
+	fmt.Fprintf(buf, "\tExit status: %d\n", result.ExitCode)
+
+	cmd.PrintErr(buf.String())
+}
+
+func mainRunLock(cmd *cobra.Command, scr *script.Script, args argparse.ParsedArgs) (*lock.Lock, error) {
+	lockPath := scr.LockPath(args)
+	if lockPath == "" {
+		return nil, nil
+	}
+
+	flags := cmd.Flags()
+	wait, _ := flags.GetBool("wait")
+	noWait, _ := flags.GetBool("no-wait")
+	waitTimeout, _ := flags.GetDuration("wait-timeout")
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+
+	defer cancel()
+
+	if waitTimeout > 0 {
+		ctx, cancel = context.WithTimeout(cmd.Context(), waitTimeout)
+
+		defer cancel()
+	}
+
+	log.Printf("acquire lock %s", lockPath)
+
+	lck, err := lock.Acquire(ctx, lockPath, wait && !noWait)
+
+	switch {
+	case errors.Is(err, lock.ErrLocked):
+		return nil, fmt.Errorf("%s is already running: %w", scr, err)
+	case err != nil:
+		return nil, fmt.Errorf("cannot acquire lock: %w", err)
+	}
+
+	return lck, nil
+}
+
+func mainRunMemoizePath(scr *script.Script, args argparse.ParsedArgs) string {
+	return filepath.Join(scr.MemoizePath(), args.Checksum())
+}
+
+func mainRunLoadMemoized(
+	cmd *cobra.Command,
+	scr *script.Script,
+	args argparse.ParsedArgs,
+) (memoize.Result, bool) {
+	if scr.Config.Memoize == 0 {
+		return memoize.Result{}, false
+	}
+
+	if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+		return memoize.Result{}, false
+	}
+
+	result, err := memoize.Load(mainRunMemoizePath(scr, args), scr.Config.Memoize)
+	if err != nil {
+		log.Printf("cannot use memoized result: %v", err)
+
+		return memoize.Result{}, false
+	}
+
+	log.Printf("use memoized result of run %s from %v", result.RunID, result.CreatedAt)
+
+	return result, true
+}
+
+func mainRunMemoizeRecorder(scr *script.Script, args argparse.ParsedArgs) *memoize.Recorder {
+	if scr.Config.Memoize == 0 {
+		return nil
+	}
+
+	recorder, err := memoize.NewRecorder(mainRunMemoizePath(scr, args), scr.ID)
+	if err != nil {
+		log.Printf("cannot memoize result: %v", err)
+
+		return nil
+	}
+
+	return recorder
+}
+
+func mainRunMemoizeResult(recorder *memoize.Recorder, result commands.ExecutionResult) {
+	if result.ExitCode != 0 || result.TimedOut {
+		if err := recorder.Discard(); err != nil {
+			log.Printf("cannot discard memoized result: %v", err)
+		}
+
+		return
+	}
+
+	if err := recorder.Commit(result.ExitCode); err != nil {
+		log.Printf("cannot memoize result: %v", err)
+	}
+}
+
+func mainRunCaptureOutput(scr *script.Script) (io.Writer, io.Writer, func(), error) {
+	conf := scr.Config.CaptureOutput
+
+	if !conf.Enabled {
+		return os.Stdout, os.Stderr, func() {}, nil
+	}
+
+	capt, err := capture.New(scr.LogsPath(), scr.ID, conf.Timestamps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	closer := func() {
+		if err := capt.Close(); err != nil {
+			log.Printf("cannot close captured output: %v", err)
+		}
+
+		if err := capture.Rotate(scr.LogsPath(), conf.MaxRuns, conf.MaxSize); err != nil {
+			log.Printf("cannot rotate captured output: %v", err)
+		}
+	}
+
+	return io.MultiWriter(os.Stdout, capt.Stdout), io.MultiWriter(os.Stderr, capt.Stderr), closer, nil
+}
+
+func mainRunAppendHistory(
+	scr *script.Script,
+	args argparse.ParsedArgs,
+	environ []string,
+	attempt int,
+	startedAt time.Time,
+	result commands.ExecutionResult,
+) {
+	chainID, _ := env.Lookup(environ, env.IDChainRun)
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		log.Printf("cannot get working directory: %v", err)
+	}
+
+	// history is a plain file: values of sensitive arguments must not
+	// get there
+	masked := args.Masked(scr.Config.Parameters, scr.Config.Positional)
+	record := history.Record{
+		Namespace:   scr.Namespace,
+		Script:      scr.Executable,
+		ID:          scr.ID,
+		ChainID:     chainID,
+		Attempt:     attempt,
+		Parameters:  masked.Parameters,
+		Flags:       masked.Flags,
+		Positional:  masked.Positional,
+		WorkingDir:  workingDir,
+		StartedAt:   startedAt,
+		ExitCode:    mainRunExitCode(result),
+		TimedOut:    result.TimedOut,
+		UserTime:    result.UserTime,
+		SystemTime:  result.SystemTime,
+		ElapsedTime: result.ElapsedTime,
+	}
+
+	if err := history.Append(scr.HistoryPath(), record); err != nil {
+		log.Printf("cannot append run to history: %v", err)
 	}
 }
@@ -1,9 +1,24 @@
 package cli_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/9seconds/chore/internal/argparse"
+	"github.com/9seconds/chore/internal/capture"
 	"github.com/9seconds/chore/internal/cli"
+	"github.com/9seconds/chore/internal/env"
+	"github.com/9seconds/chore/internal/history"
+	"github.com/9seconds/chore/internal/lock"
+	"github.com/9seconds/chore/internal/paths"
+	"github.com/9seconds/chore/internal/script"
+	"github.com/9seconds/chore/internal/vault"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -41,6 +56,719 @@ func (suite *CmdRunTestSuite) TestOk() {
 	suite.NoError(err)
 }
 
+func (suite *CmdRunTestSuite) TestNoInput() {
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("--no-input", "ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "mandatory parameter param was not provided")
+}
+
+func (suite *CmdRunTestSuite) TestDefaults() {
+	suite.EnsureScriptConfig("ns", "s", `
+[flags.flag1]
+default = true
+
+[flags.flag2]
+default = true
+
+[parameters.param]
+type = "string"
+required = true
+default = "xx"
+
+[parameters.list]
+type = "string"
+default_list = ["a", "b"]`)
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.EnsureScript(
+		"ns",
+		"s",
+		`echo -n "$CHORE_P_PARAM $CHORE_PL_LIST ${CHORE_F_FLAG1:-} ${CHORE_F_FLAG2:-}" > `+output)
+	suite.ExitMock(0).Twice()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	data, err := os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("xx a\nb 1 1", string(data))
+
+	_, err = suite.ExecuteCommand("ns", "s", "param=yy", "list=c", "_flag2")
+	suite.NoError(err)
+
+	data, err = os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("yy c 1 ", string(data))
+}
+
+func (suite *CmdRunTestSuite) TestPositional() {
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.EnsureScriptConfig("ns", "s", `
+[[positional]]
+name = "host"
+type = "string"
+required = true
+
+[[positional]]
+name = "command"
+type = "string"
+variadic = true`)
+	suite.EnsureScript(
+		"ns",
+		"s",
+		`echo -n "$CHORE_ARG_HOST|$CHORE_ARG_COMMAND|$*" > `+output)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s", "myhost", "ls", "-la")
+	suite.NoError(err)
+
+	data, err := os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("myhost|ls\n-la|myhost ls -la", string(data))
+}
+
+func (suite *CmdRunTestSuite) TestPositionalMissing() {
+	suite.EnsureScriptConfig("ns", "s", `
+[[positional]]
+name = "host"
+type = "string"
+required = true`)
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "mandatory positional argument host was not provided")
+}
+
+func (suite *CmdRunTestSuite) TestEnvironmentInherit() {
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.T().Setenv("CHORE_TEST_ALLOWED", "1")
+	suite.T().Setenv("CHORE_TEST_DENIED", "1")
+	suite.EnsureScriptConfig("ns", "s", `
+[environment]
+inherit = "allowlist"
+allow = ["CHORE_TEST_*"]
+deny = ["CHORE_TEST_DENIED"]`)
+	suite.EnsureScript(
+		"ns",
+		"s",
+		`echo -n "${CHORE_TEST_ALLOWED:-}|${CHORE_TEST_DENIED:-}|${PATH:+path}" > `+output)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	data, err := os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("1||path", string(data))
+}
+
+func (suite *CmdRunTestSuite) TestDotenv() {
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.T().Setenv("CHORE_TEST_PARENT", "parent")
+	suite.EnsureFile(paths.ConfigNamespaceDotenv("ns"), `
+export NS_VALUE="ns ${CHORE_TEST_PARENT}"
+OVERRIDEN=ns`, 0o600)
+	suite.EnsureFile(paths.ConfigNamespaceScriptDotenv("ns", "s"), `
+SCRIPT_VALUE='$NS_VALUE'
+OVERRIDEN=${NS_VALUE}/script`, 0o600)
+	suite.EnsureScript(
+		"ns",
+		"s",
+		`echo -n "$NS_VALUE|$SCRIPT_VALUE|$OVERRIDEN" > `+output)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s", "param=1")
+	suite.NoError(err)
+
+	data, err := os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("ns parent|$NS_VALUE|ns parent/script", string(data))
+}
+
+func (suite *CmdRunTestSuite) TestDotenvIncorrect() {
+	suite.EnsureFile(paths.ConfigNamespaceDotenv("ns"), `A="`, 0o600)
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s", "param=1")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "cannot load dotenv file")
+}
+
+func (suite *CmdRunTestSuite) ensureSecrets() {
+	suite.EnsureFile(paths.AppConfigPath(), `
+[vault]
+ns = "xxx"`, 0o600)
+
+	vlt, err := vault.New("xxx")
+	suite.NoError(err)
+
+	vlt.Set("github_token", "t0ken")
+	suite.NoError(vault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), vlt))
+
+	suite.EnsureScriptConfig("ns", "s", `
+[secrets]
+GITHUB_TOKEN = "github_token"`)
+}
+
+func (suite *CmdRunTestSuite) TestSecrets() {
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.ensureSecrets()
+	suite.EnsureScript("ns", "s", `echo -n "$GITHUB_TOKEN" > `+output)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	data, err := os.ReadFile(output)
+	suite.NoError(err)
+	suite.Equal("t0ken", string(data))
+}
+
+func (suite *CmdRunTestSuite) TestSecretsMissingKey() {
+	suite.ensureSecrets()
+	suite.EnsureScriptConfig("ns", "s", `
+[secrets]
+GITHUB_TOKEN = "unknown"`)
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "secret unknown is not found in vault")
+}
+
+func (suite *CmdRunTestSuite) TestSecretsNoPassword() {
+	suite.EnsureScriptConfig("ns", "s", `
+[secrets]
+GITHUB_TOKEN = "github_token"`)
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "cannot find out correct password")
+}
+
+func (suite *CmdRunTestSuite) TestSecretsExpired() {
+	output := filepath.Join(suite.T().TempDir(), "output")
+
+	suite.ensureSecrets()
+
+	vlt, err := vault.OpenFile(paths.ConfigNamespaceScriptVault("ns"), "xxx")
+	suite.NoError(err)
+
+	vlt.SetMetadata("github_token", vault.Metadata{
+		Expires: time.Now().Add(-time.Hour),
+	})
+	suite.NoError(vault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), vlt))
+
+	suite.EnsureScript("ns", "s", `echo -n "$GITHUB_TOKEN" > `+output)
+	suite.ExitMock(0).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "Secret github_token has expired")
+	suite.FileExists(output)
+
+	suite.EnsureScriptConfig("ns", "s", `
+expired_secrets = "fail"
+
+[secrets]
+GITHUB_TOKEN = "github_token"`)
+	suite.ExitMock(1).Once()
+
+	ctx, err = suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "secret github_token has expired")
+}
+
+func (suite *CmdRunTestSuite) TestSecretsScripts() {
+	suite.ensureSecrets()
+
+	vlt, err := vault.OpenFile(paths.ConfigNamespaceScriptVault("ns"), "xxx")
+	suite.NoError(err)
+
+	vlt.SetMetadata("github_token", vault.Metadata{
+		Scripts: []string{"deploy"},
+	})
+	suite.NoError(vault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), vlt))
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "script s is not allowed to read secret github_token")
+
+	vlt.SetMetadata("github_token", vault.Metadata{
+		Scripts: []string{"deploy", "s"},
+	})
+	suite.NoError(vault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), vlt))
+
+	suite.EnsureScript("ns", "s", "true")
+	suite.ExitMock(0).Once()
+
+	_, err = suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+}
+
+func (suite *CmdRunTestSuite) ensureBinarySecret(data []byte) {
+	suite.ensureSecrets()
+
+	vlt, err := vault.OpenFile(paths.ConfigNamespaceScriptVault("ns"), "xxx")
+	suite.NoError(err)
+
+	vlt.Set("ssh_key", vault.EncodeBinary(data))
+	vlt.SetMetadata("ssh_key", vault.Metadata{Binary: true})
+	suite.NoError(vault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), vlt))
+}
+
+func (suite *CmdRunTestSuite) TestSecretFiles() {
+	outputDir := suite.T().TempDir()
+	data := []byte{0, 1, 2, 0xff, '\n'}
+
+	suite.ensureBinarySecret(data)
+	suite.EnsureScriptConfig("ns", "s", `
+[secret_files]
+SSH_KEY = "ssh_key"
+TOKEN_FILE = "github_token"`)
+	suite.EnsureScript("ns", "s", fmt.Sprintf(
+		`echo -n "$SSH_KEY" > %[1]s/path && cp "$SSH_KEY" %[1]s/key && cp "$TOKEN_FILE" %[1]s/token && (stat -c %%a "$SSH_KEY" 2>/dev/null || stat -f %%Lp "$SSH_KEY") > %[1]s/mode`,
+		outputDir))
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "key"))
+	suite.NoError(err)
+	suite.Equal(data, content)
+
+	content, err = os.ReadFile(filepath.Join(outputDir, "token"))
+	suite.NoError(err)
+	suite.Equal("t0ken", string(content))
+
+	content, err = os.ReadFile(filepath.Join(outputDir, "mode"))
+	suite.NoError(err)
+	suite.Equal("600\n", string(content))
+
+	path, err := os.ReadFile(filepath.Join(outputDir, "path"))
+	suite.NoError(err)
+	suite.Equal(cli.SecretFilesDirName, filepath.Base(filepath.Dir(string(path))))
+	suite.NoFileExists(string(path))
+}
+
+func (suite *CmdRunTestSuite) TestSecretsBinary() {
+	suite.ensureBinarySecret([]byte{0})
+	suite.EnsureScriptConfig("ns", "s", `
+[secrets]
+SSH_KEY = "ssh_key"`)
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "secret ssh_key is binary")
+}
+
+func (suite *CmdRunTestSuite) TestSecretsExplain() {
+	suite.ensureSecrets()
+
+	ctx, err := suite.ExecuteCommand("--explain", "ns", "s")
+	suite.NoError(err)
+	suite.Regexp(`GITHUB_TOKEN\s+"\*\*\*"\s+secret`, ctx.Stdout.String())
+	suite.NotContains(ctx.Stdout.String(), "t0ken")
+}
+
+func (suite *CmdRunTestSuite) ensureSensitiveArgs() {
+	suite.EnsureScriptConfig("ns", "s", `
+[parameters.token]
+type = "string"
+spec = { sensitive = "true" }
+
+[parameters.user]
+type = "string"
+
+[[positional]]
+name = "password"
+type = "string"
+spec = { sensitive = "true" }`)
+	suite.EnsureScript("ns", "s", "true")
+}
+
+func (suite *CmdRunTestSuite) TestSensitiveHistory() {
+	suite.ensureSensitiveArgs()
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s", "token=t0ken", "user=me", "passw0rd")
+	suite.NoError(err)
+
+	records, err := history.Read(paths.StateNamespaceScriptHistory("ns", "s"), history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 1)
+	suite.Equal(map[string][]string{
+		"token": {env.MaskedValue},
+		"user":  {"me"},
+	}, records[0].Parameters)
+	suite.Equal([]string{env.MaskedValue}, records[0].Positional)
+}
+
+func (suite *CmdRunTestSuite) TestSensitiveExplain() {
+	suite.ensureSensitiveArgs()
+
+	ctx, err := suite.ExecuteCommand("--explain", "--json", "ns", "s", "token=t0ken", "user=me", "passw0rd")
+	suite.NoError(err)
+	suite.NotContains(ctx.Stdout.String(), "t0ken")
+	suite.NotContains(ctx.Stdout.String(), "passw0rd")
+	suite.Contains(ctx.Stdout.String(), "me")
+}
+
+func (suite *CmdRunTestSuite) TestHistory() {
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s", "param=ppp")
+	suite.NoError(err)
+
+	records, err := history.Read(paths.StateNamespaceScriptHistory("ns", "s"), history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 1)
+	suite.Equal("ns", records[0].Namespace)
+	suite.Equal("s", records[0].Script)
+	suite.Equal(map[string][]string{"param": {"ppp"}}, records[0].Parameters)
+	suite.NotEmpty(records[0].ID)
+	suite.NotEmpty(records[0].ChainID)
+	suite.NotEmpty(records[0].WorkingDir)
+	suite.Zero(records[0].ExitCode)
+}
+
+func (suite *CmdRunTestSuite) TestCaptureOutput() {
+	suite.EnsureScriptConfig("ns", "s", `
+[capture_output]
+enabled = true
+max_runs = 1`)
+	suite.EnsureScript("ns", "s", "echo out; echo err >&2")
+	suite.ExitMock(0).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.ExecuteCommand("ns", "s")
+		suite.NoError(err)
+	}
+
+	logsPath := paths.StateNamespaceScriptLogs("ns", "s")
+
+	runs, err := capture.ListRuns(logsPath)
+	suite.NoError(err)
+	suite.Len(runs, 1)
+
+	content, err := os.ReadFile(capture.StdoutPath(logsPath, runs[0]))
+	suite.NoError(err)
+	suite.Equal("out\n", string(content))
+
+	content, err = os.ReadFile(capture.StderrPath(logsPath, runs[0]))
+	suite.NoError(err)
+	suite.Equal("err\n", string(content))
+}
+
+func (suite *CmdRunTestSuite) TestTimeout() {
+	suite.EnsureScriptConfig("ns", "s", `timeout = "100ms"`)
+	suite.EnsureScript("ns", "s", "exec sleep 10")
+	suite.ExitMock(cli.ExitCodeTimeout).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "timeout 100ms has fired")
+
+	records, err := history.Read(paths.StateNamespaceScriptHistory("ns", "s"), history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 1)
+	suite.Equal(cli.ExitCodeTimeout, records[0].ExitCode)
+	suite.True(records[0].TimedOut)
+}
+
+func (suite *CmdRunTestSuite) TestLockNoWait() {
+	suite.EnsureScriptConfig("ns", "s", `lock = "script"`)
+
+	scr, err := script.New("ns", "s")
+	suite.NoError(err)
+
+	lck, err := lock.Acquire(context.Background(), scr.LockPath(argparse.ParsedArgs{}), false)
+	suite.NoError(err)
+
+	defer lck.Release()
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("--no-wait", "ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "is already running")
+}
+
+func (suite *CmdRunTestSuite) TestLockWaitTimeout() {
+	suite.EnsureScriptConfig("ns", "s", `lock = "script"`)
+
+	scr, err := script.New("ns", "s")
+	suite.NoError(err)
+
+	lck, err := lock.Acquire(context.Background(), scr.LockPath(argparse.ParsedArgs{}), false)
+	suite.NoError(err)
+
+	defer lck.Release()
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("--wait-timeout", "200ms", "ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "cannot acquire lock")
+}
+
+func (suite *CmdRunTestSuite) TestLockIsolated() {
+	suite.EnsureScriptConfig("ns", "s", `
+lock = "isolated"
+
+[parameters.param]
+type = "string"`)
+
+	scr, err := script.New("ns", "s")
+	suite.NoError(err)
+
+	args, err := argparse.Parse([]string{"param=1"}, nil)
+	suite.NoError(err)
+
+	lck, err := lock.Acquire(context.Background(), scr.LockPath(args), false)
+	suite.NoError(err)
+
+	defer lck.Release()
+
+	suite.ExitMock(0).Once()
+
+	_, err = suite.ExecuteCommand("--no-wait", "ns", "s", "param=2")
+	suite.NoError(err)
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("--no-wait", "ns", "s", "param=1")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "is already running")
+}
+
+func (suite *CmdRunTestSuite) TestLockReleased() {
+	suite.EnsureScriptConfig("ns", "s", `lock = "script"`)
+	suite.EnsureScript("ns", "s", "true")
+	suite.ExitMock(0).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.ExecuteCommand("--no-wait", "ns", "s")
+		suite.NoError(err)
+	}
+}
+
+func (suite *CmdRunTestSuite) TestMemoize() {
+	suite.EnsureScriptConfig("ns", "s", `memoize = "1h"`)
+	suite.EnsureScript("ns", "s", `echo x >> "$CHORE_PATH_DATA/counter"`)
+	suite.ExitMock(0).Times(3)
+
+	for _, args := range [][]string{{"ns", "s"}, {"ns", "s"}, {"--refresh", "ns", "s"}} {
+		_, err := suite.ExecuteCommand(args...)
+		suite.NoError(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("x\nx\n", string(content))
+}
+
+func (suite *CmdRunTestSuite) TestMemoizeFailedRun() {
+	suite.EnsureScriptConfig("ns", "s", `memoize = "1h"`)
+	suite.EnsureScript("ns", "s", `echo x >> "$CHORE_PATH_DATA/counter"; exit 3`)
+	suite.ExitMock(3).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.ExecuteCommand("ns", "s")
+		suite.NoError(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("x\nx\n", string(content))
+}
+
+func (suite *CmdRunTestSuite) TestRetry() {
+	suite.EnsureScriptConfig("ns", "s", `
+[retry]
+attempts = 3
+delay = "10ms"`)
+	suite.EnsureScript("ns", "s", `
+echo "$CHORE_RETRY_ATTEMPT" >> "$CHORE_PATH_DATA/counter"
+[ "$CHORE_RETRY_ATTEMPT" = 3 ]`)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("1\n2\n3\n", string(content))
+
+	records, err := history.Read(paths.StateNamespaceScriptHistory("ns", "s"), history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 3)
+
+	for i, record := range records {
+		suite.Equal(i+1, record.Attempt)
+		suite.Equal(records[0].ChainID, record.ChainID)
+
+		if i > 0 {
+			suite.NotEqual(records[i-1].ID, record.ID)
+		}
+	}
+}
+
+func (suite *CmdRunTestSuite) TestRetryExitCodes() {
+	suite.EnsureScriptConfig("ns", "s", `
+[retry]
+attempts = 3
+delay = "10ms"
+on_exit_codes = [2]`)
+	suite.EnsureScript("ns", "s", `
+echo "$CHORE_RETRY_ATTEMPT" >> "$CHORE_PATH_DATA/counter"
+exit $(( CHORE_RETRY_ATTEMPT + 1 ))`)
+	suite.ExitMock(3).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("1\n2\n", string(content))
+}
+
+func (suite *CmdRunTestSuite) TestRetryInterrupted() {
+	suite.EnsureScriptConfig("ns", "s", `
+[retry]
+attempts = 3
+delay = "10ms"`)
+	suite.EnsureScript("ns", "s", `
+echo "$CHORE_RETRY_ATTEMPT" >> "$CHORE_PATH_DATA/counter"
+kill -INT $$`)
+	suite.ExitMock(cli.ExitCodeSignalBase + int(syscall.SIGINT)).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("1\n", string(content))
+
+	records, err := history.Read(paths.StateNamespaceScriptHistory("ns", "s"), history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 1)
+	suite.Equal(cli.ExitCodeSignalBase+int(syscall.SIGINT), records[0].ExitCode)
+}
+
+func (suite *CmdRunTestSuite) TestRetrySignal() {
+	suite.EnsureScriptConfig("ns", "s", fmt.Sprintf(`
+[retry]
+attempts = 3
+delay = "10ms"
+on_exit_codes = [%d]`, cli.ExitCodeSignalBase+int(syscall.SIGUSR1)))
+	suite.EnsureScript("ns", "s", `
+echo "$CHORE_RETRY_ATTEMPT" >> "$CHORE_PATH_DATA/counter"
+[ "$CHORE_RETRY_ATTEMPT" = 2 ] || kill -USR1 $$`)
+	suite.ExitMock(0).Once()
+
+	_, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	content, err := os.ReadFile(filepath.Join(paths.DataNamespaceScript("ns", "s"), "counter"))
+	suite.NoError(err)
+	suite.Equal("1\n2\n", string(content))
+}
+
+func (suite *CmdRunTestSuite) TestLimits() {
+	suite.EnsureScriptConfig("ns", "s", `
+[limits]
+file_size = "1K"`)
+	suite.EnsureScript("ns", "s", `exec head -c 4096 /dev/zero > "$CHORE_PATH_DATA/file"`)
+	suite.ExitMock(cli.ExitCodeSignalBase + int(syscall.SIGXFSZ)).Once()
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "file_size limit 1024 bytes was exceeded")
+}
+
+func (suite *CmdRunTestSuite) TestTime() {
+	suite.EnsureScript("ns", "s", "true")
+	suite.ExitMock(0).Once()
+
+	ctx, err := suite.ExecuteCommand("--time", "ns", "s", "param=1")
+	suite.NoError(err)
+
+	stderr := ctx.Stderr.String()
+	suite.Contains(stderr, `Command being timed: "ns/s"`)
+	suite.Contains(stderr, "Maximum resident set size (kbytes): ")
+	suite.Contains(stderr, "Terminating signal: -")
+	suite.Contains(stderr, "Exit status: 0")
+}
+
+func (suite *CmdRunTestSuite) TestExplain() {
+	marker := filepath.Join(suite.T().TempDir(), "marker")
+
+	suite.EnsureScriptConfig("ns", "s", `
+git = "no"
+
+[parameters.param]
+type = "string"`)
+	suite.EnsureScript("ns", "s", "touch "+marker)
+
+	ctx, err := suite.ExecuteCommand("--explain", "ns", "s", "param=1", "--", "pos")
+	suite.NoError(err)
+	suite.NoFileExists(marker)
+
+	stdout := ctx.Stdout.String()
+	suite.Contains(stdout, `"pos"`)
+	suite.Contains(stdout, "Working directory:")
+	suite.Regexp(`CHORE_P_PARAM\s+"1"\s+parameter`, stdout)
+	suite.Regexp(`CHORE_NAMESPACE\s+"ns"\s+script`, stdout)
+	suite.Regexp(`CHORE_RETRY_ATTEMPT\s+"1"\s+run`, stdout)
+	suite.Regexp(`git\s+git access mode is no`, stdout)
+}
+
+func (suite *CmdRunTestSuite) TestExplainJSON() {
+	ctx, err := suite.ExecuteCommand("--explain", "--json", "ns", "s", "param=1")
+	suite.NoError(err)
+
+	explanation := struct {
+		Argv        []string `json:"argv"`
+		WorkingDir  string   `json:"working_dir"`
+		Environment []struct {
+			Name   string `json:"name"`
+			Value  string `json:"value"`
+			Source string `json:"source"`
+		} `json:"environment"`
+	}{}
+
+	suite.NoError(json.Unmarshal(ctx.Stdout.Bytes(), &explanation))
+
+	scr, err := script.New("ns", "s")
+	suite.NoError(err)
+
+	suite.Equal([]string{scr.Path()}, explanation.Argv)
+	suite.NotEmpty(explanation.WorkingDir)
+
+	sources := map[string]string{}
+
+	for _, v := range explanation.Environment {
+		sources[v.Name] = v.Source
+	}
+
+	suite.Equal("parameter", sources["CHORE_P_PARAM"])
+	suite.Equal("generator:os", sources["CHORE_OS_TYPE"])
+	suite.Equal("parent", sources["PATH"])
+}
+
 func TestCmdRun(t *testing.T) {
 	suite.Run(t, &CmdRunTestSuite{})
 }
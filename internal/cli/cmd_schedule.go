@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/commands"
+	"github.com/9seconds/chore/internal/scheduler"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewSchedule() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:     "schedule",
+		Aliases: []string{"sc"},
+		Short:   "Run scripts according to schedules from their configs",
+	}
+
+	rootCmd.AddCommand(
+		&cobra.Command{
+			Use:     "list [namespace]",
+			Aliases: []string{"l"},
+			Short:   "List scheduled scripts with their next run times",
+			Args: cobra.MatchAll(
+				cobra.MaximumNArgs(1),
+				validators.ArgumentOptional(0, validators.Namespace(0)),
+			),
+			Run:                   base.Main(mainScheduleList),
+			ValidArgsFunction:     completions.CompleteNamespaces,
+			DisableFlagsInUseLine: true,
+		},
+		newScheduleDaemon("daemon", []string{"d"}))
+
+	return rootCmd
+}
+
+// NewScheduler is a top-level shortcut for chore schedule daemon.
+func NewScheduler() *cobra.Command {
+	return newScheduleDaemon("scheduler", nil)
+}
+
+func newScheduleDaemon(use string, aliases []string) *cobra.Command {
+	return &cobra.Command{
+		Use:                   use,
+		Aliases:               aliases,
+		Short:                 "Run scheduled scripts in foreground until interrupted",
+		Args:                  cobra.NoArgs,
+		Run:                   base.Main(mainScheduleDaemon),
+		DisableFlagsInUseLine: true,
+	}
+}
+
+func mainScheduleList(cmd *cobra.Command, args []string) error {
+	jobs, err := scheduler.Discover()
+	if err != nil {
+		return fmt.Errorf("cannot discover scheduled scripts: %w", err)
+	}
+
+	buf := &strings.Builder{}
+	writer := mainTabwriter(buf)
+	now := time.Now()
+
+	for _, job := range jobs {
+		if len(args) > 0 && job.Namespace != args[0] {
+			continue
+		}
+
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\n",
+			job,
+			job.Schedule.Spec,
+			job.Schedule.Next(now).Format(time.RFC3339))
+	}
+
+	writer.Flush()
+
+	cmd.Print(buf.String())
+
+	return nil
+}
+
+func mainScheduleDaemon(cmd *cobra.Command, _ []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find out chore executable: %w", err)
+	}
+
+	globalArgs := mainScheduleGlobalArgs(cmd)
+
+	sched := scheduler.New(func(ctx context.Context, job scheduler.Job) error {
+		runArgs := append([]string{}, globalArgs...)
+		runArgs = append(runArgs, "run", job.Namespace, job.Executable)
+		runCmd := exec.CommandContext(ctx, executable, runArgs...)
+
+		runCmd.Stdout = os.Stdout
+		runCmd.Stderr = os.Stderr
+		runCmd.Cancel = func() error {
+			return runCmd.Process.Signal(commands.SignalInterrupt)
+		}
+		runCmd.WaitDelay = commands.StopGracefulPeriod
+
+		cmd.PrintErrf("%s: run %s\n", time.Now().Format(time.RFC3339), job)
+
+		err := runCmd.Run()
+		if err != nil {
+			cmd.PrintErrf("%s: %s has failed: %v\n", time.Now().Format(time.RFC3339), job, err)
+		}
+
+		return err
+	})
+
+	sched.Run(cmd.Context())
+
+	return nil
+}
+
+// mainScheduleGlobalArgs returns global flags which were given to the
+// daemon so scheduled runs get the same settings.
+func mainScheduleGlobalArgs(cmd *cobra.Command) []string {
+	args := []string{}
+
+	cmd.Root().Flags().Visit(func(flag *pflag.Flag) {
+		switch flag.Name {
+		case "help", "version":
+			return
+		}
+
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range values.GetSlice() {
+				args = append(args, "--"+flag.Name+"="+value)
+			}
+
+			return
+		}
+
+		args = append(args, "--"+flag.Name+"="+flag.Value.String())
+	})
+
+	return args
+}
@@ -0,0 +1,48 @@
+package cli_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/9seconds/chore/internal/cli"
+	"github.com/stretchr/testify/suite"
+)
+
+type CmdScheduleTestSuite struct {
+	CmdTestSuite
+}
+
+func (suite *CmdScheduleTestSuite) SetupTest() {
+	suite.CmdTestSuite.Setup("schedule", cli.NewSchedule)
+
+	suite.EnsureScript("ns", "a", "echo 1")
+	suite.EnsureScriptConfig("ns", "a", `schedule = "@hourly"`)
+	suite.EnsureScript("ns", "b", "echo 1")
+	suite.EnsureScript("xx", "c", "echo 1")
+	suite.EnsureScriptConfig("xx", "c", `every = "10m"`)
+}
+
+func (suite *CmdScheduleTestSuite) TestList() {
+	ctx, err := suite.ExecuteCommand("list")
+	suite.NoError(err)
+
+	lines := ctx.StdoutLines()
+	suite.Len(lines, 2)
+	suite.True(strings.HasPrefix(lines[0], "ns/a"))
+	suite.Contains(lines[0], "@hourly")
+	suite.True(strings.HasPrefix(lines[1], "xx/c"))
+	suite.Contains(lines[1], "@every 10m0s")
+}
+
+func (suite *CmdScheduleTestSuite) TestListNamespace() {
+	ctx, err := suite.ExecuteCommand("list", "xx")
+	suite.NoError(err)
+
+	lines := ctx.StdoutLines()
+	suite.Len(lines, 1)
+	suite.True(strings.HasPrefix(lines[0], "xx/c"))
+}
+
+func TestCmdSchedule(t *testing.T) {
+	suite.Run(t, &CmdScheduleTestSuite{})
+}
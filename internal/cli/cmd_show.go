@@ -18,6 +18,7 @@
 	"github.com/9seconds/chore/internal/cli/completions"
 	"github.com/9seconds/chore/internal/cli/validators"
 	"github.com/9seconds/chore/internal/script"
+	scriptconfig "github.com/9seconds/chore/internal/script/config"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +28,9 @@
 
 	ByteBase = 1024
 
+	FlagDefaultSet   = "set"
+	FlagDefaultClear = "clear"
+
 	RequiredTrue  = "✔"
 	RequiredFalse = "✖"
 
@@ -186,6 +190,7 @@ func mainShowTables(cmd *cobra.Command, scr *script.Script) {
 	mainShowDescription(buf, scr)
 	mainShowMainTable(buf, scr, dirSizes)
 	mainShowTableParameters(buf, scr)
+	mainShowTablePositional(buf, scr)
 	mainShowTableFlags(buf, scr)
 
 	cmd.Println(strings.TrimRightFunc(buf.String(), unicode.IsSpace))
@@ -248,23 +253,51 @@ func mainShowTableParameters(buf io.Writer, scr *script.Script) {
 
 	defer writer.Flush()
 
-	fmt.Fprintln(writer, "Parameter\tDescription\tRequired?\tType\tSpecification")
-	fmt.Fprintln(writer, "╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴╴╴")
+	fmt.Fprintln(writer, "Parameter\tDescription\tRequired?\tType\tDefault\tSpecification")
+	fmt.Fprintln(writer, "╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴\t╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴╴╴")
 
 	for _, name := range names {
 		param := scr.Config.Parameters[name]
 
 		fmt.Fprintf(
 			writer,
-			"%s\t%s\t%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
 			name,
 			param.Description(),
 			mainShowRequired(param.Required()),
 			param.Type(),
+			mainShowParameterDefault(param),
 			mainShowParameterSpec(param.Specification()))
 	}
 }
 
+func mainShowTablePositional(buf io.Writer, scr *script.Script) {
+	if len(scr.Config.Positional) == 0 {
+		return
+	}
+
+	defer io.WriteString(buf, "\n") //nolint: errcheck
+
+	writer := mainTabwriter(buf)
+
+	defer writer.Flush()
+
+	fmt.Fprintln(writer, "Positional\tDescription\tRequired?\tVariadic?\tType\tSpecification")
+	fmt.Fprintln(writer, "╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴╴╴")
+
+	for _, arg := range scr.Config.Positional {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			arg.Name,
+			arg.Parameter.Description(),
+			mainShowRequired(arg.Parameter.Required()),
+			mainShowRequired(arg.Variadic),
+			arg.Parameter.Type(),
+			mainShowParameterSpec(arg.Parameter.Specification()))
+	}
+}
+
 func mainShowTableFlags(buf io.Writer, scr *script.Script) {
 	if len(scr.Config.Flags) == 0 {
 		return
@@ -296,18 +329,19 @@ func mainShowTableFlags(buf io.Writer, scr *script.Script) {
 
 	defer writer.Flush()
 
-	fmt.Fprintln(writer, "Flag\tDescription\tRequired?")
-	fmt.Fprintln(writer, "╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴")
+	fmt.Fprintln(writer, "Flag\tDescription\tRequired?\tDefault")
+	fmt.Fprintln(writer, "╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴")
 
 	for _, name := range names {
 		flag := scr.Config.Flags[name]
 
 		fmt.Fprintf(
 			writer,
-			"%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\n",
 			name,
 			flag.Description(),
-			mainShowRequired(flag.Required()))
+			mainShowRequired(flag.Required()),
+			mainShowFlagDefault(flag))
 	}
 }
 
@@ -363,6 +397,34 @@ func mainShowParameterSpec(spec map[string]string) string {
 	return strings.Join(kvs, " ")
 }
 
+func mainShowParameterDefault(param scriptconfig.Parameter) string {
+	values := param.Default()
+	if values == nil {
+		return ""
+	}
+
+	quoted := make([]string, 0, len(values))
+
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func mainShowFlagDefault(flag scriptconfig.Flag) string {
+	value, ok := flag.Default()
+
+	switch {
+	case !ok:
+		return ""
+	case value:
+		return FlagDefaultSet
+	}
+
+	return FlagDefaultClear
+}
+
 func mainTabwriter(writer io.Writer) *tabwriter.Writer {
 	return tabwriter.NewWriter(writer, 0, TabSize, 1, '\t', 0)
 }
@@ -127,6 +127,37 @@ func (suite *CmdShowTestSuite) TestShow() { //nolint: cyclop
 	suite.Empty(seen)
 }
 
+func (suite *CmdShowTestSuite) TestShowDefaults() {
+	suite.EnsureScriptConfig("ns", "s", `
+[flags.flag1]
+default = true
+
+[parameters.param]
+type = "string"
+default_list = ["a", "b c"]`)
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+
+	stdout := ctx.Stdout.String()
+	suite.Regexp(`param\s+.*\s+"a" "b c"`, stdout)
+	suite.Regexp(`flag1\s+.*\s+set`, stdout)
+}
+
+func (suite *CmdShowTestSuite) TestShowPositional() {
+	suite.EnsureScriptConfig("ns", "s", `
+[[positional]]
+name = "host"
+type = "string"
+required = true
+description = "where to connect"`)
+
+	ctx, err := suite.ExecuteCommand("ns", "s")
+	suite.NoError(err)
+	suite.Contains(ctx.Stdout.String(), "Positional")
+	suite.Regexp(`host\s+where to connect\s+✔\s+✖\s+string`, ctx.Stdout.String())
+}
+
 func TestCmdShow(t *testing.T) {
 	suite.Run(t, &CmdShowTestSuite{})
 }
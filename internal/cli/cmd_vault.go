@@ -16,7 +16,11 @@ func NewVault() *cobra.Command {
 		vault.NewList(),
 		vault.NewGet(),
 		vault.NewSet(),
-		vault.NewDelete())
+		vault.NewDelete(),
+		vault.NewUpgrade(),
+		vault.NewRekey(),
+		vault.NewExport(),
+		vault.NewImport())
 
 	return rootCmd
 }
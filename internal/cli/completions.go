@@ -8,6 +8,7 @@
 	"github.com/9seconds/chore/internal/argparse"
 	"github.com/9seconds/chore/internal/cli/completions"
 	"github.com/9seconds/chore/internal/script"
+	scriptconfig "github.com/9seconds/chore/internal/script/config"
 	"github.com/spf13/cobra"
 )
 
@@ -19,15 +20,12 @@ func completeRun(cmd *cobra.Command, args []string, toComplete string) ([]string
 		return completions.CompleteNamespaceScript(cmd, args, toComplete)
 	}
 
-	parsed, err := argparse.Parse(args[2:])
+	parsed, err := argparse.Parse(args[2:], nil)
 
-	switch {
-	case err != nil:
+	if err != nil {
 		log.Printf("cannot parse arguments: %v", err)
 
 		return nil, cobra.ShellCompDirectiveError
-	case parsed.IsPositionalTime():
-		return nil, cobra.ShellCompDirectiveDefault
 	}
 
 	scr, err := script.New(args[0], args[1])
@@ -37,6 +35,10 @@ func completeRun(cmd *cobra.Command, args []string, toComplete string) ([]string
 		return nil, cobra.ShellCompDirectiveError
 	}
 
+	if parsed.IsPositionalTime() {
+		return completeRunPositional(scr, parsed, toComplete)
+	}
+
 	completions := []string{}
 	directive := cobra.ShellCompDirectiveNoFileComp
 
@@ -55,7 +57,13 @@ func completeRun(cmd *cobra.Command, args []string, toComplete string) ([]string
 			directive = cobra.ShellCompDirectiveNoSpace
 		}
 
-		if descr := param.Description(); descr != "" {
+		descr := param.Description()
+
+		if values := param.Default(); values != nil {
+			descr = strings.TrimSpace(descr + " (default: " + strings.Join(values, ", ") + ")")
+		}
+
+		if descr != "" {
 			completion += "\t" + descr
 		}
 
@@ -70,9 +78,21 @@ func completeRun(cmd *cobra.Command, args []string, toComplete string) ([]string
 		negative := string(argparse.PrefixFlagClear) + name
 		positive := string(argparse.PrefixFlag) + name
 
-		if descr := flag.Description(); descr != "" {
-			negative += "\t" + descr + " (clear)"
-			positive += "\t" + descr + " (set)"
+		descr := flag.Description()
+		clearHint := "clear"
+		setHint := "set"
+		value, hasDefault := flag.Default()
+
+		switch {
+		case hasDefault && value:
+			setHint += ", default"
+		case hasDefault:
+			clearHint += ", default"
+		}
+
+		if descr != "" || hasDefault {
+			negative += "\t" + strings.TrimSpace(descr+" ("+clearHint+")")
+			positive += "\t" + strings.TrimSpace(descr+" ("+setHint+")")
 		}
 
 		if toComplete == "" || strings.HasPrefix(negative, toComplete) {
@@ -92,3 +112,40 @@ func completeRun(cmd *cobra.Command, args []string, toComplete string) ([]string
 
 	return nil, cobra.ShellCompDirectiveDefault
 }
+
+func completeRunPositional(
+	scr *script.Script,
+	parsed argparse.ParsedArgs,
+	toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	idx := len(parsed.Positional)
+
+	for i, arg := range scr.Config.Positional {
+		if i != idx && !(arg.Variadic && i < idx) {
+			continue
+		}
+
+		withChoices, ok := arg.Parameter.(scriptconfig.ParameterWithChoices)
+		if !ok {
+			break
+		}
+
+		completions := []string{}
+
+		for _, choice := range withChoices.Choices() {
+			if !strings.HasPrefix(choice, toComplete) {
+				continue
+			}
+
+			if descr := arg.Parameter.Description(); descr != "" {
+				choice += "\t" + descr
+			}
+
+			completions = append(completions, choice)
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nil, cobra.ShellCompDirectiveDefault
+}
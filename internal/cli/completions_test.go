@@ -171,6 +171,62 @@ func (suite *CompleteRunTestSuite) TestCompleteScriptWithConfigFlag() {
 	suite.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
 }
 
+func (suite *CompleteRunTestSuite) TestCompleteScriptWithDefaults() {
+	suite.EnsureScript("xx", "y", "")
+	suite.EnsureScriptConfig("xx", "y", `
+[parameters.param]
+type = "string"
+description = "param description"
+default = "value"
+
+[flags.flag1]
+default = true
+	`)
+
+	values, directive := completeRun(suite.cmd, []string{"xx", "y"}, "")
+
+	suite.Equal([]string{
+		"+flag1\t(set, default)",
+		"_flag1\t(clear)",
+		"param=\tparam description (default: value)",
+	}, values)
+	suite.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func (suite *CompleteRunTestSuite) TestCompletePositional() {
+	suite.EnsureScript("xx", "y", "")
+	suite.EnsureScriptConfig("xx", "y", `
+[[positional]]
+name = "host"
+type = "string"
+
+[[positional]]
+name = "action"
+type = "enum"
+description = "what to do"
+variadic = true
+spec = { choices = "start,stop,status" }
+	`)
+
+	values, directive := completeRun(suite.cmd, []string{"xx", "y", "--"}, "")
+
+	suite.Empty(values)
+	suite.Equal(cobra.ShellCompDirectiveDefault, directive)
+
+	values, directive = completeRun(suite.cmd, []string{"xx", "y", "host"}, "st")
+
+	suite.Equal([]string{
+		"start\twhat to do",
+		"status\twhat to do",
+		"stop\twhat to do",
+	}, values)
+	suite.Equal(cobra.ShellCompDirectiveNoFileComp, directive)
+
+	values, _ = completeRun(suite.cmd, []string{"xx", "y", "host", "start"}, "sto")
+
+	suite.Equal([]string{"stop\twhat to do"}, values)
+}
+
 func TestCompleteRun(t *testing.T) {
 	suite.Run(t, &CompleteRunTestSuite{})
 }
@@ -47,7 +47,11 @@ func openEditor(ctx context.Context, editor, path string) error {
 		env.Environ(),
 		os.Stdin,
 		os.Stdout,
-		os.Stderr)
+		os.Stderr,
+		commands.ExecutionPolicy{
+			// some editors spawn a server process on the first start
+			KeepOrphans: true,
+		})
 
 	if err := cmd.Start(ctx); err != nil {
 		return fmt.Errorf("cannot start editor: %w", err)
@@ -1,7 +1,11 @@
 package cli_test
 
 import (
+	"os"
+	"testing"
+
 	"github.com/9seconds/chore/internal/cli"
+	"github.com/9seconds/chore/internal/commands"
 	"github.com/9seconds/chore/internal/testlib"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/suite"
@@ -22,3 +26,12 @@ func (suite *CmdTestSuite) Setup(subcommand string, makeCommand func() *cobra.Co
 		return root
 	})
 }
+
+// TestMain makes a test binary work as a limits shim, as chore does.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == commands.LimitsShimCommand {
+		commands.RunLimitsShim(os.Args[2:])
+	}
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,67 @@
+package vault
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+func NewExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "export [flags] namespace",
+		Aliases:           []string{"e"},
+		Short:             "Export vault secrets",
+		ValidArgsFunction: completions.CompleteNamespaces,
+		Args:              cobra.MatchAll(cobra.ExactArgs(1), validators.Namespace(0)),
+		Run: base.Main(main(func(cmd *cobra.Command, vlt vault.Vault, _ []string) (bool, error) {
+			formatName, _ := cmd.Flags().GetString("format")
+
+			format, err := vault.GetExportFormat(formatName)
+			if err != nil {
+				return false, fmt.Errorf("incorrect format %s: %w", formatName, err)
+			}
+
+			passphrase := ""
+
+			if format == vault.ExportFormatBundle {
+				passphrase, err = readPassphrase(cmd, true)
+				if err != nil {
+					return false, fmt.Errorf("cannot read passphrase: %w", err)
+				}
+			}
+
+			var writer io.Writer = cmd.OutOrStdout()
+
+			if output, _ := cmd.Flags().GetString("output"); output != "" {
+				file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint: gomnd
+				if err != nil {
+					return false, fmt.Errorf("cannot open output file: %w", err)
+				}
+
+				defer file.Close()
+
+				writer = file
+			}
+
+			if err := vault.Export(writer, vlt, format, passphrase); err != nil {
+				return false, fmt.Errorf("cannot export vault: %w", err)
+			}
+
+			return false, nil
+		})),
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringP("format", "f", vault.ExportFormatDotenv.String(), "export format: dotenv, json or bundle")
+	flags.StringP("output", "o", "", "a file to write to. Default is stdout")
+	flags.String("passphrase-env", "", "an environment variable with a bundle passphrase")
+
+	return cmd
+}
@@ -2,6 +2,7 @@
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/9seconds/chore/internal/cli/base"
 	"github.com/9seconds/chore/internal/cli/validators"
@@ -29,6 +30,19 @@ func NewGet() *cobra.Command {
 				return false, ErrKeyUnknown
 			}
 
+			if metadata, _ := vlt.Metadata(args[0]); metadata.Binary {
+				data, err := metadata.Decode(value)
+				if err != nil {
+					return false, fmt.Errorf("cannot decode value: %w", err)
+				}
+
+				if _, err := cmd.OutOrStdout().Write(data); err != nil {
+					return false, fmt.Errorf("cannot write value: %w", err)
+				}
+
+				return false, nil
+			}
+
 			cmd.Println(value)
 
 			return false, nil
@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+func NewImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "import [flags] namespace file",
+		Aliases: []string{"i"},
+		Short:   "Import vault secrets. Use - to read from stdin",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completions.CompleteNamespaces(cmd, args, toComplete)
+			}
+
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		Args: cobra.MatchAll(
+			cobra.ExactArgs(2), //nolint: gomnd
+			validators.Namespace(0),
+		),
+		Run: base.Main(main(func(cmd *cobra.Command, vlt vault.Vault, args []string) (bool, error) {
+			formatName, _ := cmd.Flags().GetString("format")
+
+			format, err := vault.GetExportFormat(formatName)
+			if err != nil {
+				return false, fmt.Errorf("incorrect format %s: %w", formatName, err)
+			}
+
+			strategyName, _ := cmd.Flags().GetString("strategy")
+
+			strategy, err := vault.GetImportStrategy(strategyName)
+			if err != nil {
+				return false, fmt.Errorf("incorrect strategy %s: %w", strategyName, err)
+			}
+
+			var reader io.Reader = cmd.InOrStdin()
+
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return false, fmt.Errorf("cannot open file: %w", err)
+				}
+
+				defer file.Close()
+
+				reader = file
+			}
+
+			passphrase := ""
+
+			if format == vault.ExportFormatBundle {
+				passphrase, err = readPassphrase(cmd, false)
+				if err != nil {
+					return false, fmt.Errorf("cannot read passphrase: %w", err)
+				}
+			}
+
+			secrets, err := vault.Import(reader, format, passphrase)
+			if err != nil {
+				return false, fmt.Errorf("cannot import secrets: %w", err)
+			}
+
+			imported, err := strategy.Apply(vlt, secrets)
+			if err != nil {
+				return false, fmt.Errorf("cannot import secrets: %w", err)
+			}
+
+			cmd.PrintErrf("Imported %d of %d secrets\n", imported, len(secrets))
+
+			return true, nil
+		})),
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringP("format", "f", vault.ExportFormatDotenv.String(), "import format: dotenv, json or bundle")
+	flags.StringP("strategy", "s", vault.ImportStrategyMerge.String(), "how to import: merge, overwrite or skip-existing")
+	flags.String("passphrase-env", "", "an environment variable with a bundle passphrase")
+
+	return cmd
+}
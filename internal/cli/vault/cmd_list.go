@@ -1,7 +1,11 @@
 package vault
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/9seconds/chore/internal/cli/base"
 	"github.com/9seconds/chore/internal/cli/completions"
@@ -10,19 +14,26 @@
 	"github.com/spf13/cobra"
 )
 
+const listTabSize = 8
+
 func NewList() *cobra.Command {
-	return &cobra.Command{
-		Use:                   "list namespace",
-		Aliases:               []string{"l"},
-		Short:                 "List keys of a vault secrets",
-		ValidArgsFunction:     completions.CompleteNamespaces,
-		DisableFlagsInUseLine: true,
-		Args:                  cobra.MatchAll(cobra.ExactArgs(1), validators.Namespace(0)),
+	cmd := &cobra.Command{
+		Use:               "list [flags] namespace",
+		Aliases:           []string{"l"},
+		Short:             "List keys of a vault secrets",
+		ValidArgsFunction: completions.CompleteNamespaces,
+		Args:              cobra.MatchAll(cobra.ExactArgs(1), validators.Namespace(0)),
 		Run: base.Main(main(func(cmd *cobra.Command, vlt vault.Vault, _ []string) (bool, error) {
 			keys := vlt.List()
 
 			sort.Strings(keys)
 
+			if long, _ := cmd.Flags().GetBool("long"); long {
+				mainListLong(cmd, vlt, keys)
+
+				return false, nil
+			}
+
 			for _, v := range keys {
 				cmd.Println(v)
 			}
@@ -30,4 +41,57 @@ func NewList() *cobra.Command {
 			return false, nil
 		})),
 	}
+
+	cmd.Flags().BoolP("long", "l", false, "show metadata of secrets")
+
+	return cmd
+}
+
+func mainListLong(cmd *cobra.Command, vlt vault.Vault, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	now := time.Now()
+	buf := &strings.Builder{}
+	writer := tabwriter.NewWriter(buf, 0, listTabSize, 1, '\t', 0)
+
+	fmt.Fprintln(writer, "Key\tCreated\tUpdated\tExpires\tScripts\tDescription")
+	fmt.Fprintln(writer, "╴╴╴\t╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴\t╴╴╴╴╴╴╴╴╴╴╴")
+
+	for _, key := range keys {
+		metadata, _ := vlt.Metadata(key)
+		expires := mainListTime(metadata.Expires)
+
+		if metadata.IsExpired(now) {
+			expires += " (expired)"
+		}
+
+		scripts := "*"
+		if len(metadata.Scripts) > 0 {
+			scripts = strings.Join(metadata.Scripts, ",")
+		}
+
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			key,
+			mainListTime(metadata.Created),
+			mainListTime(metadata.Updated),
+			expires,
+			scripts,
+			metadata.Description)
+	}
+
+	writer.Flush()
+
+	cmd.Print(buf.String())
+}
+
+func mainListTime(value time.Time) string {
+	if value.IsZero() {
+		return "-"
+	}
+
+	return value.Local().Format(time.RFC3339)
 }
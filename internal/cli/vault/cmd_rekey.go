@@ -0,0 +1,197 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/config"
+	"github.com/9seconds/chore/internal/paths"
+	"github.com/9seconds/chore/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+var ErrSamePassword = errors.New("new password is the same as the current one")
+
+func NewRekey() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "rekey [flags] namespace",
+		Aliases:           []string{"r"},
+		Short:             "Re-encrypt a vault with a new password",
+		ValidArgsFunction: completions.CompleteNamespaces,
+		Args:              cobra.MatchAll(cobra.ExactArgs(1), validators.Namespace(0)),
+		Run:               base.Main(mainRekey),
+	}
+
+	flags := cmd.Flags()
+
+	flags.Bool("generate", false, "generate a new password instead of asking for it")
+	flags.Uint32("kdf-time", 0, "a number of Argon2id passes")
+	flags.Uint32("kdf-memory", 0, "Argon2id memory in MiB")
+	flags.Uint8("kdf-threads", 0, "Argon2id parallelism")
+
+	return cmd
+}
+
+func mainRekey(cmd *cobra.Command, args []string) error {
+	nsVault, err := openVault(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	generate, _ := cmd.Flags().GetBool("generate")
+
+	var password string
+
+	if generate {
+		password = config.GeneratePassword()
+	} else {
+		password, err = mainSetReadFromTerminal(cmd)
+		if err != nil {
+			return fmt.Errorf("cannot read new password: %w", err)
+		}
+	}
+
+	if password == nsVault.password {
+		return ErrSamePassword
+	}
+
+	params, err := mainRekeyParams(cmd, nsVault.vault)
+	if err != nil {
+		return err
+	}
+
+	rekeyed, err := vault.ReencryptWithParams(nsVault.vault, password, params)
+	if err != nil {
+		return fmt.Errorf("cannot re-encrypt vault: %w", err)
+	}
+
+	backupPath := paths.ConfigNamespaceScriptVaultBackup(nsVault.namespace)
+
+	backedUp, err := vault.BackupFile(nsVault.path, backupPath)
+	if err != nil {
+		return fmt.Errorf("cannot backup vault: %w", err)
+	}
+
+	if backedUp {
+		cmd.PrintErrf("Previous vault is saved to %s\n", backupPath)
+	}
+
+	if err := vault.SaveFile(nsVault.path, rekeyed); err != nil {
+		return fmt.Errorf("cannot save vault: %w", err)
+	}
+
+	mainRekeyReportChanges(cmd, nsVault.vault, rekeyed)
+
+	err = mainRekeyUpdatePassword(cmd, nsVault, password, generate)
+	if err == nil {
+		return nil
+	}
+
+	// a vault is already encrypted with a new password so it has to be
+	// either restored or a new password has to be shown. Otherwise, it
+	// is impossible to open the vault anymore.
+	if restoreErr := mainRekeyRestore(nsVault.path, backupPath, backedUp); restoreErr != nil {
+		cmd.PrintErrf("Cannot restore previous vault: %v\n", restoreErr)
+		cmd.PrintErrln("Vault is encrypted with a new password:")
+		cmd.Println(password)
+	} else {
+		cmd.PrintErrln("Previous vault is restored")
+	}
+
+	return err
+}
+
+// mainRekeyRestore puts a previous vault back.
+func mainRekeyRestore(path, backupPath string, backedUp bool) error {
+	if !backedUp {
+		return os.Remove(path)
+	}
+
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("cannot read backup: %w", err)
+	}
+
+	return vault.WriteFile(path, data)
+}
+
+// mainRekeyReportChanges tells about changes which are made implicitly
+// on re-encryption.
+func mainRekeyReportChanges(cmd *cobra.Command, before, after vault.Vault) {
+	if before.Version() != after.Version() {
+		cmd.PrintErrf("Vault is upgraded from version %d to %d\n", before.Version(), after.Version())
+	}
+
+	oldParams, ok := vault.GetParams(before)
+	newParams, _ := vault.GetParams(after)
+
+	if ok && oldParams != newParams {
+		cmd.PrintErrf(
+			"KDF parameters are changed: time %d -> %d, memory %d KiB -> %d KiB, threads %d -> %d\n",
+			oldParams.Time, newParams.Time,
+			oldParams.Memory, newParams.Memory,
+			oldParams.Threads, newParams.Threads)
+	}
+}
+
+// mainRekeyParams returns KDF parameters for a new vault. Parameters
+// which are not set by flags are taken from the current vault or
+// defaults if it has none.
+func mainRekeyParams(cmd *cobra.Command, current vault.Vault) (vault.Params, error) {
+	params, ok := vault.GetParams(current)
+	if !ok {
+		params = vault.DefaultParams
+	}
+
+	flags := cmd.Flags()
+
+	if flags.Changed("kdf-time") {
+		params.Time, _ = flags.GetUint32("kdf-time")
+	}
+
+	if flags.Changed("kdf-memory") {
+		memory, _ := flags.GetUint32("kdf-memory")
+		// clamp to avoid overflow, validation rejects such values anyway
+		if memory > math.MaxUint32/1024 {
+			memory = math.MaxUint32 / 1024
+		}
+
+		params.Memory = memory * 1024
+	}
+
+	if flags.Changed("kdf-threads") {
+		params.Threads, _ = flags.GetUint8("kdf-threads")
+	}
+
+	return params, params.Validate()
+}
+
+// mainRekeyUpdatePassword updates a password file if it is a source of
+// the password. Otherwise, it shows what has to be updated.
+func mainRekeyUpdatePassword(cmd *cobra.Command, nsVault namespaceVault, password string, generated bool) error {
+	source := nsVault.passwordSource
+
+	switch {
+	case source.File != "":
+		if err := vault.WriteFile(source.File, []byte(password+"\n")); err != nil {
+			return fmt.Errorf("cannot update password file: %w", err)
+		}
+
+		cmd.PrintErrf("Password file %s is updated\n", source.File)
+	case source.Command == "" && source.Env == "" && !source.Prompt:
+		cmd.PrintErrln("Please update [vault] section of the application config:")
+		cmd.Printf("%s = %q\n", nsVault.namespace, password)
+	case generated:
+		cmd.PrintErrln("Please update your password storage with a new password:")
+		cmd.Println(password)
+	default:
+		cmd.PrintErrln("Please update your password storage with a new password")
+	}
+
+	return nil
+}
@@ -5,6 +5,9 @@
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/9seconds/chore/internal/cli/base"
 	"github.com/9seconds/chore/internal/cli/validators"
@@ -13,15 +16,21 @@
 	"golang.org/x/term"
 )
 
-var ErrStdinIsNotTerminal = errors.New("stdin is not connected to a valid terminal")
+var (
+	ErrStdinIsNotTerminal = errors.New("stdin is not connected to a valid terminal")
+	ErrValueAndFile       = errors.New("value and --file cannot be used together")
+)
+
+// ExpiresDateLayout is a layout of --expires flag value. RFC3339 is
+// accepted too.
+const ExpiresDateLayout = "2006-01-02"
 
 func NewSet() *cobra.Command {
-	return &cobra.Command{
-		Use:                   "set namespace key [value]",
-		Aliases:               []string{"s"},
-		Short:                 "Set a vault secret",
-		ValidArgsFunction:     completeSecretKey,
-		DisableFlagsInUseLine: true,
+	cmd := &cobra.Command{
+		Use:               "set [flags] namespace key [value]",
+		Aliases:           []string{"s"},
+		Short:             "Set a vault secret",
+		ValidArgsFunction: completeSecretKey,
 		Args: cobra.MatchAll(
 			cobra.RangeArgs(2, 3), //nolint: gomnd
 			validators.Namespace(0),
@@ -32,9 +41,16 @@ func NewSet() *cobra.Command {
 				err   error
 			)
 
-			if len(args) == 1 {
+			filePath, _ := cmd.Flags().GetString("file")
+
+			switch {
+			case filePath != "" && len(args) > 1:
+				return false, ErrValueAndFile
+			case filePath != "":
+				value, err = mainSetReadFromFile(filePath)
+			case len(args) == 1:
 				value, err = mainSetReadFromTerminal(cmd)
-			} else {
+			default:
 				value = args[1]
 			}
 
@@ -42,11 +58,103 @@ func NewSet() *cobra.Command {
 				return true, fmt.Errorf("cannot set value: %w", err)
 			}
 
-			vlt.Set(args[0], value)
+			if err := mainSetValue(cmd, vlt, args[0], value); err != nil {
+				return false, err
+			}
 
 			return true, nil
 		})),
 	}
+
+	flags := cmd.Flags()
+
+	flags.String("description", "", "a description of the secret")
+	flags.String("expires", "", "a date when the secret expires (YYYY-MM-DD). Empty value clears it")
+	flags.StringP("file", "f", "", "read a value from a file. Binary content is supported")
+	flags.StringSlice("scripts", nil, "scripts of the namespace which can read the secret. Empty value allows all")
+
+	cmd.RegisterFlagCompletionFunc("scripts", completeScripts) //nolint: errcheck
+
+	return cmd
+}
+
+func mainSetReadFromFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read file: %w", err)
+	}
+
+	return vault.EncodeBinary(data), nil
+}
+
+// mainSetValue sets a value and updates metadata of the secret
+// according to given flags.
+func mainSetValue(cmd *cobra.Command, vlt vault.Vault, key, value string) error {
+	flags := cmd.Flags()
+	changed := flags.Changed("description") ||
+		flags.Changed("expires") ||
+		flags.Changed("scripts") ||
+		flags.Changed("file")
+
+	if !vault.SupportsMetadata(vlt) && changed {
+		return fmt.Errorf(
+			"vault of version %d has no metadata, please run 'chore vault upgrade'",
+			vlt.Version())
+	}
+
+	vlt.Set(key, value)
+
+	metadata, _ := vlt.Metadata(key)
+	metadata.Binary = flags.Changed("file")
+
+	if flags.Changed("description") {
+		metadata.Description, _ = flags.GetString("description")
+	}
+
+	if flags.Changed("expires") {
+		expiresValue, _ := flags.GetString("expires")
+
+		expires, err := parseExpires(expiresValue)
+		if err != nil {
+			return fmt.Errorf("incorrect expiration date %s: %w", expiresValue, err)
+		}
+
+		metadata.Expires = expires
+	}
+
+	if flags.Changed("scripts") {
+		scripts, _ := flags.GetStringSlice("scripts")
+		metadata.Scripts = nil
+
+		for _, name := range scripts {
+			if name = strings.TrimSpace(name); name != "" {
+				metadata.Scripts = append(metadata.Scripts, name)
+			}
+		}
+
+		sort.Strings(metadata.Scripts)
+	}
+
+	vlt.SetMetadata(key, metadata)
+
+	return nil
+}
+
+func parseExpires(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if expires, err := time.ParseInLocation(ExpiresDateLayout, value, time.Local); err == nil {
+		return expires.UTC(), nil
+	}
+
+	expires, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return expires.UTC(), nil
 }
 
 func mainSetReadFromTerminal(cmd *cobra.Command) (string, error) {
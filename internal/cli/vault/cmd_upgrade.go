@@ -0,0 +1,50 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/9seconds/chore/internal/cli/base"
+	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/cli/validators"
+	"github.com/9seconds/chore/internal/vault"
+	"github.com/spf13/cobra"
+)
+
+func NewUpgrade() *cobra.Command {
+	return &cobra.Command{
+		Use:                   "upgrade namespace",
+		Aliases:               []string{"u"},
+		Short:                 "Re-encrypt a vault with the latest format",
+		ValidArgsFunction:     completions.CompleteNamespaces,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), validators.Namespace(0)),
+		Run: base.Main(func(cmd *cobra.Command, args []string) error {
+			nsVault, err := openVault(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			if int(nsVault.vault.Version()) == vault.LatestVersion {
+				cmd.PrintErrf("Vault already has the latest version %d\n", vault.LatestVersion)
+
+				return nil
+			}
+
+			upgraded, err := vault.Reencrypt(nsVault.vault, nsVault.password)
+			if err != nil {
+				return fmt.Errorf("cannot upgrade vault: %w", err)
+			}
+
+			if err := vault.SaveFile(nsVault.path, upgraded); err != nil {
+				return fmt.Errorf("cannot save vault: %w", err)
+			}
+
+			cmd.PrintErrf(
+				"Vault was upgraded from version %d to %d\n",
+				nsVault.vault.Version(),
+				upgraded.Version())
+
+			return nil
+		}),
+	}
+}
@@ -5,6 +5,7 @@
 	"sort"
 
 	"github.com/9seconds/chore/internal/cli/completions"
+	"github.com/9seconds/chore/internal/script"
 	"github.com/9seconds/chore/internal/vault"
 	"github.com/spf13/cobra"
 )
@@ -59,3 +60,24 @@ func completeSecretKey(
 
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
+
+func completeScripts(
+	_ *cobra.Command,
+	args []string,
+	_ string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	namespace, _ := script.ExtractRealNamespace(args[0])
+
+	scripts, err := script.ListScripts(namespace)
+	if err != nil {
+		log.Printf("cannot get a list of scripts: %v", err)
+
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return scripts, cobra.ShellCompDirectiveNoFileComp
+}
@@ -1,10 +1,10 @@
 package vault
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io/fs"
-	"os"
+	"io"
 
 	"github.com/9seconds/chore/internal/config"
 	"github.com/9seconds/chore/internal/paths"
@@ -13,63 +13,77 @@
 	"github.com/spf13/cobra"
 )
 
+var ErrPasswordNotStatic = errors.New("password cannot be resolved without user interaction")
+
 type mainCallback func(*cobra.Command, vault.Vault, []string) (bool, error)
 
+type namespaceVault struct {
+	vault          vault.Vault
+	namespace      string
+	path           string
+	password       string
+	passwordSource config.VaultPassword
+}
+
 func main(callback mainCallback) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
-		namespace, _ := script.ExtractRealNamespace(args[0])
-
-		conf, err := config.Get()
-		if err != nil {
-			return fmt.Errorf("cannot get application config: %w", err)
-		}
-
-		if _, ok := conf.Vault[namespace]; !ok {
-			return fmt.Errorf("cannot find out correct password for namespace %s", namespace)
-		}
-
-		vaultPath := paths.ConfigNamespaceScriptVault(namespace)
-
-		vlt, err := getVault(vaultPath, conf.Vault[namespace])
+		nsVault, err := openVault(cmd, args[0])
 		if err != nil {
-			return fmt.Errorf("cannot open vault: %w", err)
+			return err
 		}
 
-		save, err := callback(cmd, vlt, args[1:])
+		save, err := callback(cmd, nsVault.vault, args[1:])
 
 		switch {
 		case err != nil:
 			return err
 		case save:
-			return saveVault(vaultPath, vlt)
+			return vault.SaveFile(nsVault.path, nsVault.vault)
 		}
 
 		return nil
 	}
 }
 
-func getVault(path, password string) (vault.Vault, error) {
-	reader, err := os.Open(path)
+func openVault(cmd *cobra.Command, namespace string) (namespaceVault, error) {
+	namespace, _ = script.ExtractRealNamespace(namespace)
 
-	switch {
-	case errors.Is(err, fs.ErrNotExist):
-		return vault.New(password)
-	case err != nil:
-		return nil, fmt.Errorf("cannot open vault: %w", err)
+	conf, err := config.Get()
+	if err != nil {
+		return namespaceVault{}, fmt.Errorf("cannot get application config: %w", err)
 	}
 
-	defer reader.Close()
-
-	return vault.Open(reader, password)
-}
+	// completions have no command: they are executed on each keypress
+	// so they never run password commands or ask for a password
+	ctx := context.Background()
+	input := io.Reader(nil)
+	output := io.Discard
+
+	if cmd != nil {
+		ctx = cmd.Context()
+		input = cmd.InOrStdin()
+		output = cmd.ErrOrStderr()
+	} else if !conf.Vault[namespace].IsStatic() {
+		return namespaceVault{}, ErrPasswordNotStatic
+	}
 
-func saveVault(path string, vlt vault.Vault) error {
-	writer, err := os.Create(path)
+	password, err := conf.GetVaultPassword(ctx, namespace, input, output)
 	if err != nil {
-		return fmt.Errorf("cannot open file: %w", err)
+		return namespaceVault{}, err
 	}
 
-	defer writer.Close()
+	vaultPath := paths.ConfigNamespaceScriptVault(namespace)
+
+	vlt, err := vault.OpenFile(vaultPath, password)
+	if err != nil {
+		return namespaceVault{}, fmt.Errorf("cannot open vault: %w", err)
+	}
 
-	return vault.Save(writer, vlt)
+	return namespaceVault{
+		vault:          vlt,
+		namespace:      namespace,
+		path:           vaultPath,
+		password:       password,
+		passwordSource: conf.Vault[namespace],
+	}, nil
 }
@@ -1,12 +1,19 @@
 package vault_test
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/9seconds/chore/internal/cli/edit"
 	"github.com/9seconds/chore/internal/cli/vault"
 	"github.com/9seconds/chore/internal/paths"
 	"github.com/9seconds/chore/internal/testlib"
+	chorevault "github.com/9seconds/chore/internal/vault"
+	v1 "github.com/9seconds/chore/internal/vault/v1"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/suite"
 )
@@ -25,7 +32,11 @@ func (suite *VaultTestSuite) SetupTest() {
 			vault.NewList(),
 			vault.NewDelete(),
 			vault.NewSet(),
-			vault.NewGet())
+			vault.NewGet(),
+			vault.NewUpgrade(),
+			vault.NewRekey(),
+			vault.NewExport(),
+			vault.NewImport())
 
 		return cmd
 	})
@@ -36,7 +47,10 @@ func (suite *VaultTestSuite) SetupTest() {
 	suite.EnsureFile(paths.AppConfigPath(), `
 [vault]
 z = ""
-ns = "xxx"`, edit.ConfigDefaultPermission)
+ns = "xxx"
+
+[vault.env]
+password_env = "CHORE_TEST_VAULT_PASSWORD"`, edit.ConfigDefaultPermission)
 }
 
 func (suite *VaultTestSuite) TestUnknownPassword() {
@@ -55,6 +69,27 @@ func (suite *VaultTestSuite) TestEmptyPassword() {
 	suite.Contains(ctx.Stderr.String(), "password is empty")
 }
 
+func (suite *VaultTestSuite) TestPasswordSource() {
+	suite.EnsureScript("env", "s", "echo 1")
+	suite.T().Setenv("CHORE_TEST_VAULT_PASSWORD", "xxx")
+
+	_, err := suite.ExecuteCommand("set", "env", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("get", "env", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+}
+
+func (suite *VaultTestSuite) TestPasswordSourceFailed() {
+	suite.EnsureScript("env", "s", "echo 1")
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("list", "env")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "CHORE_TEST_VAULT_PASSWORD")
+}
+
 func (suite *VaultTestSuite) TestKeyUnknown() {
 	suite.ExitMock(1).Once()
 
@@ -90,6 +125,313 @@ func (suite *VaultTestSuite) TestCRUD() {
 	suite.Empty(ctx.StderrLines())
 }
 
+func (suite *VaultTestSuite) TestUpgrade() {
+	old, err := v1.NewVault("xxx")
+	suite.NoError(err)
+
+	old.Set("k", "v")
+
+	path := paths.ConfigNamespaceScriptVault("ns")
+	suite.NoError(chorevault.SaveFile(path, old))
+
+	ctx, err := suite.ExecuteCommand("upgrade", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "upgraded from version 1 to 2")
+
+	data, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.EqualValues(chorevault.LatestVersion, data[0])
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+
+	ctx, err = suite.ExecuteCommand("upgrade", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "already has the latest version")
+}
+
+func (suite *VaultTestSuite) TestCompletionSkipsPasswordCommand() {
+	marker := filepath.Join(suite.T().TempDir(), "marker")
+
+	suite.EnsureFile(paths.AppConfigPath(), `
+[vault.ns]
+password_command = "sh -c 'touch `+marker+`; echo xxx'"`, edit.ConfigDefaultPermission)
+
+	_, err := suite.ExecuteCommand("__complete", "get", "ns", "")
+	suite.NoError(err)
+	suite.NoFileExists(marker)
+}
+
+func (suite *VaultTestSuite) TestRekey() {
+	_, err := suite.ExecuteCommand("set", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("rekey", "--generate", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "Previous vault is saved to")
+
+	lines := ctx.StdoutLines()
+	suite.Len(lines, 1)
+	suite.Regexp(`^ns = ".+"$`, lines[0])
+
+	backup, err := chorevault.OpenFile(paths.ConfigNamespaceScriptVaultBackup("ns"), "xxx")
+	suite.NoError(err)
+
+	value, ok := backup.Get("k")
+	suite.True(ok)
+	suite.Equal("v", value)
+
+	suite.ExitMock(1).Once()
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "password")
+
+	suite.EnsureFile(paths.AppConfigPath(), "[vault]\n"+lines[0], edit.ConfigDefaultPermission)
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+}
+
+func (suite *VaultTestSuite) TestRekeyPasswordFile() {
+	passwordFile := filepath.Join(suite.T().TempDir(), "password")
+
+	suite.NoError(os.WriteFile(passwordFile, []byte("xxx"), 0o600))
+	suite.EnsureFile(paths.AppConfigPath(), `
+[vault.ns]
+password_file = "`+passwordFile+`"`, edit.ConfigDefaultPermission)
+
+	_, err := suite.ExecuteCommand("set", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("rekey", "--generate", "ns")
+	suite.NoError(err)
+	suite.Empty(ctx.StdoutLines())
+	suite.Contains(ctx.Stderr.String(), "is updated")
+
+	data, err := os.ReadFile(passwordFile)
+	suite.NoError(err)
+	suite.NotEqual("xxx", string(data))
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+}
+
+func (suite *VaultTestSuite) TestRekeyParams() {
+	_, err := suite.ExecuteCommand("set", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand(
+		"rekey", "--generate", "--kdf-time", "1", "--kdf-memory", "8", "--kdf-threads", "1", "ns")
+	suite.NoError(err)
+
+	lines := ctx.StdoutLines()
+	suite.Len(lines, 1)
+
+	password, err := strconv.Unquote(strings.TrimPrefix(lines[0], "ns = "))
+	suite.NoError(err)
+
+	box, err := chorevault.OpenFile(paths.ConfigNamespaceScriptVault("ns"), password)
+	suite.NoError(err)
+
+	params, ok := chorevault.GetParams(box)
+	suite.True(ok)
+	suite.Equal(chorevault.Params{Time: 1, Memory: 8 * 1024, Threads: 1}, params)
+
+	suite.EnsureFile(paths.AppConfigPath(), "[vault]\n"+lines[0], edit.ConfigDefaultPermission)
+
+	ctx, err = suite.ExecuteCommand("rekey", "--generate", "--kdf-time", "2", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "KDF parameters are changed: time 1 -> 2, memory 8192 KiB -> 8192 KiB")
+
+	password, err = strconv.Unquote(strings.TrimPrefix(ctx.StdoutLines()[0], "ns = "))
+	suite.NoError(err)
+
+	box, err = chorevault.OpenFile(paths.ConfigNamespaceScriptVault("ns"), password)
+	suite.NoError(err)
+
+	params, _ = chorevault.GetParams(box)
+	suite.Equal(chorevault.Params{Time: 2, Memory: 8 * 1024, Threads: 1}, params)
+}
+
+func (suite *VaultTestSuite) TestRekeyUpgrade() {
+	old, err := v1.NewVault("xxx")
+	suite.NoError(err)
+
+	old.Set("k", "v")
+	suite.NoError(chorevault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), old))
+
+	ctx, err := suite.ExecuteCommand("rekey", "--generate", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "Vault is upgraded from version 1 to 2")
+}
+
+func (suite *VaultTestSuite) TestRekeyIncorrectParams() {
+	_, err := suite.ExecuteCommand("set", "ns", "k", "v")
+	suite.NoError(err)
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("rekey", "--generate", "--kdf-memory", "100000", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "incorrect KDF parameters")
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+}
+
+func (suite *VaultTestSuite) TestExportImport() {
+	output := filepath.Join(suite.T().TempDir(), "bundle")
+
+	suite.T().Setenv("CHORE_TEST_PASSPHRASE", "passphrase")
+
+	_, err := suite.ExecuteCommand("set", "--description", "desc", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("export", "-f", "json", "ns")
+	suite.NoError(err)
+
+	exported := map[string]map[string]interface{}{}
+
+	suite.NoError(json.Unmarshal(ctx.Stdout.Bytes(), &exported))
+	suite.Equal("v", exported["k"]["value"])
+	suite.Equal("desc", exported["k"]["description"])
+
+	_, err = suite.ExecuteCommand(
+		"export", "-f", "bundle", "--passphrase-env", "CHORE_TEST_PASSPHRASE", "-o", output, "ns")
+	suite.NoError(err)
+	suite.FileExists(output)
+
+	_, err = suite.ExecuteCommand("set", "ns", "k", "v2")
+	suite.NoError(err)
+
+	ctx, err = suite.ExecuteCommand(
+		"import", "-f", "bundle", "-s", "skip-existing", "--passphrase-env", "CHORE_TEST_PASSPHRASE", "ns", output)
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "Imported 0 of 1 secrets")
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v2")
+
+	ctx, err = suite.ExecuteCommand(
+		"import", "-f", "bundle", "--passphrase-env", "CHORE_TEST_PASSPHRASE", "ns", output)
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "Imported 1 of 1 secrets")
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Contains(ctx.StdoutLines(), "v")
+
+	ctx, err = suite.ExecuteCommand("list", "-l", "ns")
+	suite.NoError(err)
+	suite.Contains(ctx.Stdout.String(), "desc")
+}
+
+func (suite *VaultTestSuite) TestImportIncorrectFormat() {
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("import", "-f", "xml", "ns", "-")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "invalid export format")
+}
+
+func (suite *VaultTestSuite) TestMetadata() {
+	_, err := suite.ExecuteCommand(
+		"set", "--description", "GitHub token", "--expires", "2000-01-01", "ns", "k", "v")
+	suite.NoError(err)
+
+	_, err = suite.ExecuteCommand("set", "ns", "k2", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("list", "ns")
+	suite.NoError(err)
+	suite.Equal([]string{"k", "k2"}, ctx.StdoutLines())
+
+	ctx, err = suite.ExecuteCommand("list", "--long", "ns")
+	suite.NoError(err)
+
+	lines := ctx.StdoutLines()
+	suite.Len(lines, 4)
+	suite.Regexp(`^k\s+\S+\s+\S+\s+2000-01-01\S+ \(expired\)\s+\*\s+GitHub token$`, lines[2])
+	suite.Regexp(`^k2\s+\S+\s+\S+\s+-\s+\*\s*$`, lines[3])
+
+	_, err = suite.ExecuteCommand("set", "--expires", "", "ns", "k", "v2")
+	suite.NoError(err)
+
+	ctx, err = suite.ExecuteCommand("list", "--long", "ns")
+	suite.NoError(err)
+	suite.NotContains(ctx.Stdout.String(), "expired")
+	suite.Contains(ctx.Stdout.String(), "GitHub token")
+}
+
+func (suite *VaultTestSuite) TestScripts() {
+	_, err := suite.ExecuteCommand("set", "--scripts", "deploy,s", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("list", "--long", "ns")
+	suite.NoError(err)
+	suite.Regexp(`^k\s+\S+\s+\S+\s+-\s+deploy,s\s*$`, ctx.StdoutLines()[2])
+
+	_, err = suite.ExecuteCommand("set", "--scripts", "", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err = suite.ExecuteCommand("list", "--long", "ns")
+	suite.NoError(err)
+	suite.Regexp(`^k\s+\S+\s+\S+\s+-\s+\*\s*$`, ctx.StdoutLines()[2])
+}
+
+func (suite *VaultTestSuite) TestMetadataUnsupported() {
+	old, err := v1.NewVault("xxx")
+	suite.NoError(err)
+	suite.NoError(chorevault.SaveFile(paths.ConfigNamespaceScriptVault("ns"), old))
+
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("set", "--scripts", "s", "ns", "k", "v")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "chore vault upgrade")
+}
+
+func (suite *VaultTestSuite) TestSetFile() {
+	data := []byte{0, 1, 2, 0xff}
+	path := filepath.Join(suite.T().TempDir(), "key")
+
+	suite.NoError(os.WriteFile(path, data, 0o600))
+
+	_, err := suite.ExecuteCommand("set", "--file", path, "ns", "k")
+	suite.NoError(err)
+
+	ctx, err := suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Equal(data, ctx.Stdout.Bytes())
+
+	_, err = suite.ExecuteCommand("set", "ns", "k", "v")
+	suite.NoError(err)
+
+	ctx, err = suite.ExecuteCommand("get", "ns", "k")
+	suite.NoError(err)
+	suite.Equal("v\n", ctx.Stdout.String())
+
+	suite.ExitMock(1).Once()
+
+	ctx, err = suite.ExecuteCommand("set", "--file", path, "ns", "k", "v")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "cannot be used together")
+}
+
+func (suite *VaultTestSuite) TestMetadataIncorrectExpires() {
+	suite.ExitMock(1).Once()
+
+	ctx, err := suite.ExecuteCommand("set", "--expires", "tomorrow", "ns", "k", "v")
+	suite.NoError(err)
+	suite.Contains(ctx.Stderr.String(), "incorrect expiration date")
+}
+
 func TestVault(t *testing.T) {
 	suite.Run(t, &VaultTestSuite{})
 }
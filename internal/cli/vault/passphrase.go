@@ -0,0 +1,47 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/9seconds/chore/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var ErrPassphraseMismatch = errors.New("passphrases do not match")
+
+// readPassphrase reads a passphrase of a bundle either from environment
+// variable set by --passphrase-env flag or from a terminal.
+func readPassphrase(cmd *cobra.Command, confirm bool) (string, error) {
+	if name, _ := cmd.Flags().GetString("passphrase-env"); name != "" {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return "", fmt.Errorf("environment variable %s is empty", name)
+		}
+
+		return value, nil
+	}
+
+	if !prompt.IsInteractive(cmd.InOrStdin()) {
+		return "", ErrStdinIsNotTerminal
+	}
+
+	prmpt := prompt.New(cmd.InOrStdin(), cmd.ErrOrStderr())
+
+	passphrase, err := prmpt.Ask("Bundle passphrase", true, nil)
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+
+	repeat, err := prmpt.Ask("Repeat passphrase", true, nil)
+
+	switch {
+	case err != nil:
+		return "", err
+	case repeat != passphrase:
+		return "", ErrPassphraseMismatch
+	}
+
+	return passphrase, nil
+}
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"os"
 	"time"
 )
 
@@ -11,9 +12,49 @@ type Command interface {
 	Wait() ExecutionResult
 }
 
+type ExecutionPolicy struct {
+	// Timeout defines how long command can be executed. 0 means that
+	// there is no timeout.
+	Timeout time.Duration
+
+	// StopSignal is a signal to send to stop command gracefully. If
+	// nil, SignalInterrupt is used.
+	StopSignal os.Signal
+
+	// StopGracePeriod is a time to wait after StopSignal is sent. When
+	// it is over, a command is killed. If 0, StopGracefulPeriod is used.
+	StopGracePeriod time.Duration
+
+	// KeepOrphans disables killing of processes of the command process
+	// group that are still alive after command has exited.
+	KeepOrphans bool
+
+	// Limits are resource limits of the command.
+	Limits Limits
+}
+
 type ExecutionResult struct {
 	ExitCode    int
+	TimedOut    bool
 	UserTime    time.Duration
 	SystemTime  time.Duration
 	ElapsedTime time.Duration
+
+	// Signal is a signal that has terminated the command. It is nil
+	// if command has exited by itself.
+	Signal os.Signal
+
+	// Stopped tells that chore has sent a stop signal to the command
+	// because of timeout or cancellation.
+	Stopped bool
+
+	// MaxRSS is a maximal resident set size in bytes.
+	MaxRSS int64
+
+	MinorPageFaults            int64
+	MajorPageFaults            int64
+	BlockInput                 int64
+	BlockOutput                int64
+	VoluntaryContextSwitches   int64
+	InvoluntaryContextSwitches int64
 }
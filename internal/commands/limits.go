@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// LimitsShimCommand is a hidden command of chore which sets resource
+// limits and executes a real command. chore has to dispatch it before
+// anything else: see RunLimitsShim.
+const LimitsShimCommand = "__limits"
+
+// LimitsShimExitCode is an exit code of the shim if it cannot execute
+// a command. It is the same code that shells use.
+const LimitsShimExitCode = 126
+
+// Limits are resource limits applied to a command. 0 means that chore
+// does not limit a resource.
+type Limits struct {
+	// Memory is a maximal size of virtual memory in bytes.
+	Memory uint64
+
+	// CPUTime is a maximal CPU time. When it is exceeded, a command
+	// gets SIGXCPU and SIGKILL a second after.
+	CPUTime time.Duration
+
+	OpenFiles uint64
+
+	// Processes is a maximal number of processes of the user. Please
+	// pay attention that this limit is per user, not per command.
+	Processes uint64
+
+	// FileSize is a maximal size of a file command can write in bytes.
+	FileSize uint64
+
+	// Core is a maximal size of a core dump in bytes.
+	Core uint64
+}
+
+func (l Limits) IsEmpty() bool {
+	return l == Limits{}
+}
+
+// ExplainSignal returns a human-readable reason why a command was
+// terminated by a given signal if it was caused by some limit. Empty
+// string means that it is not related to limits.
+//
+// Only hard cpu_time limit kills with SIGKILL. A memory limit makes
+// allocations fail so a command usually crashes. Please pay attention
+// that chore itself kills a command on timeout or stop: such signals
+// have nothing to do with limits.
+func (l Limits) ExplainSignal(sig os.Signal) string {
+	switch {
+	case (sig == syscall.SIGXCPU || sig == syscall.SIGKILL) && l.CPUTime > 0:
+		return fmt.Sprintf("cpu_time limit %v was probably exceeded", l.CPUTime)
+	case sig == syscall.SIGXFSZ && l.FileSize > 0:
+		return fmt.Sprintf("file_size limit %d bytes was exceeded", l.FileSize)
+	case (sig == syscall.SIGSEGV || sig == syscall.SIGABRT) && l.Memory > 0:
+		return fmt.Sprintf("memory limit %d bytes was probably exceeded", l.Memory)
+	}
+
+	return ""
+}
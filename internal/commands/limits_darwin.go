@@ -0,0 +1,25 @@
+//go:build darwin
+
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+)
+
+func osWrapLimits(_ *exec.Cmd, limits Limits) error {
+	if !limits.IsEmpty() {
+		log.Printf("resource limits are not supported on this platform")
+	}
+
+	return nil
+}
+
+// RunLimitsShim is never used on this platform: limits are not
+// applied so commands are executed directly.
+func RunLimitsShim(_ []string) {
+	fmt.Fprintln(os.Stderr, "chore: resource limits are not supported on this platform")
+	os.Exit(LimitsShimExitCode)
+}
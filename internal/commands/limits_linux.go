@@ -0,0 +1,152 @@
+//go:build linux
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// CPUTimeHardLimitGap is a difference between soft and hard CPU time
+// limits. Soft limit sends SIGXCPU, hard one kills.
+const CPUTimeHardLimitGap = time.Second
+
+type osRlimit struct {
+	name     string
+	resource int
+	soft     uint64
+	hard     uint64
+}
+
+// osWrapLimits makes the command to be executed by the limits shim.
+// Limits have to be set before a command is executed, otherwise its
+// children could be started without them.
+func osWrapLimits(cmd *exec.Cmd, limits Limits) error {
+	// command cannot be started anyway. Let exec report why.
+	if cmd.Err != nil {
+		return nil
+	}
+
+	rlimits, err := osMakeRlimits(limits)
+	if err != nil || len(rlimits) == 0 {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find out chore executable: %w", err)
+	}
+
+	encoded := make([]string, 0, len(rlimits))
+
+	for _, limit := range rlimits {
+		encoded = append(encoded, fmt.Sprintf("%d:%d:%d", limit.resource, limit.soft, limit.hard))
+	}
+
+	cmd.Args = append(
+		[]string{executable, LimitsShimCommand, strings.Join(encoded, ","), cmd.Path},
+		cmd.Args...)
+	cmd.Path = executable
+
+	return nil
+}
+
+// osMakeRlimits converts limits into rlimits which could be set by
+// chore: unprivileged process cannot raise a hard limit.
+func osMakeRlimits(limits Limits) ([]osRlimit, error) {
+	rlimits := []osRlimit{}
+
+	if limits.Memory > 0 {
+		rlimits = append(rlimits, osRlimit{"memory", unix.RLIMIT_AS, limits.Memory, limits.Memory})
+	}
+
+	if limits.CPUTime > 0 {
+		seconds := uint64(math.Ceil(limits.CPUTime.Seconds()))
+		gap := uint64(CPUTimeHardLimitGap.Seconds())
+
+		rlimits = append(rlimits, osRlimit{"cpu_time", unix.RLIMIT_CPU, seconds, seconds + gap})
+	}
+
+	if limits.OpenFiles > 0 {
+		rlimits = append(rlimits, osRlimit{"open_files", unix.RLIMIT_NOFILE, limits.OpenFiles, limits.OpenFiles})
+	}
+
+	if limits.Processes > 0 {
+		rlimits = append(rlimits, osRlimit{"processes", unix.RLIMIT_NPROC, limits.Processes, limits.Processes})
+	}
+
+	if limits.FileSize > 0 {
+		rlimits = append(rlimits, osRlimit{"file_size", unix.RLIMIT_FSIZE, limits.FileSize, limits.FileSize})
+	}
+
+	if limits.Core > 0 {
+		rlimits = append(rlimits, osRlimit{"core", unix.RLIMIT_CORE, limits.Core, limits.Core})
+	}
+
+	errs := []error{}
+
+	for idx := range rlimits {
+		limit := &rlimits[idx]
+		current := unix.Rlimit{}
+
+		if err := unix.Getrlimit(limit.resource, &current); err != nil {
+			errs = append(errs, fmt.Errorf("cannot get current %s limit: %w", limit.name, err))
+
+			continue
+		}
+
+		if limit.hard > current.Max {
+			limit.hard = current.Max
+		}
+
+		if limit.soft > limit.hard {
+			limit.soft = limit.hard
+		}
+	}
+
+	return rlimits, errors.Join(errs...)
+}
+
+// RunLimitsShim sets resource limits and replaces chore with
+// a command. Arguments are encoded limits, a path to the command and
+// its argv. It never returns.
+func RunLimitsShim(args []string) {
+	if len(args) < 3 { //nolint: gomnd
+		osExitLimitsShim(errors.New("command is not defined"))
+	}
+
+	for _, chunk := range strings.Split(args[0], ",") {
+		fields := strings.Split(chunk, ":")
+		if len(fields) != 3 { //nolint: gomnd
+			osExitLimitsShim(fmt.Errorf("incorrect limit %s", chunk))
+		}
+
+		resource, err1 := strconv.Atoi(fields[0])
+		soft, err2 := strconv.ParseUint(fields[1], 10, 64)
+		hard, err3 := strconv.ParseUint(fields[2], 10, 64)
+
+		if err := errors.Join(err1, err2, err3); err != nil {
+			osExitLimitsShim(fmt.Errorf("incorrect limit %s: %w", chunk, err))
+		}
+
+		if err := unix.Setrlimit(resource, &unix.Rlimit{Cur: soft, Max: hard}); err != nil {
+			osExitLimitsShim(fmt.Errorf("cannot set limit %s: %w", chunk, err))
+		}
+	}
+
+	osExitLimitsShim(syscall.Exec(args[1], args[2:], os.Environ()))
+}
+
+func osExitLimitsShim(err error) {
+	fmt.Fprintf(os.Stderr, "chore: cannot execute command with resource limits: %v\n", err)
+	os.Exit(LimitsShimExitCode)
+}
@@ -0,0 +1,44 @@
+package commands_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsExplainSignal(t *testing.T) {
+	limits := commands.Limits{
+		Memory:   1024,
+		CPUTime:  time.Second,
+		FileSize: 1024,
+	}
+
+	testTable := map[os.Signal]string{
+		syscall.SIGXCPU: "cpu_time limit 1s was probably exceeded",
+		syscall.SIGKILL: "cpu_time limit 1s was probably exceeded",
+		syscall.SIGXFSZ: "file_size limit 1024 bytes was exceeded",
+		syscall.SIGSEGV: "memory limit 1024 bytes was probably exceeded",
+		syscall.SIGTERM: "",
+	}
+
+	for sig, expected := range testTable {
+		sig := sig
+		expected := expected
+
+		t.Run(sig.String(), func(t *testing.T) {
+			assert.Equal(t, expected, limits.ExplainSignal(sig))
+			assert.Empty(t, commands.Limits{}.ExplainSignal(sig))
+		})
+	}
+}
+
+func TestLimitsExplainSignalMemoryOnly(t *testing.T) {
+	limits := commands.Limits{Memory: 1024}
+
+	assert.Empty(t, limits.ExplainSignal(syscall.SIGKILL))
+	assert.Equal(t, "memory limit 1024 bytes was probably exceeded", limits.ExplainSignal(syscall.SIGSEGV))
+}
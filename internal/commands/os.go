@@ -3,17 +3,28 @@
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os/exec"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+const OrphansWaitDelay = time.Second
+
+var ErrTimeout = errors.New("execution timeout")
+
 type osCommand struct {
 	cmd       *exec.Cmd
+	policy    ExecutionPolicy
 	waiters   *sync.WaitGroup
 	startTime time.Time
+	ctx       context.Context
 	cancel    context.CancelFunc
+	ttyFd     int
+	stopped   atomic.Bool
 }
 
 func (o *osCommand) Pid() int {
@@ -25,11 +36,32 @@ func (o *osCommand) Pid() int {
 }
 
 func (o *osCommand) Start(ctx context.Context) error {
-	ctx, cancel := context.WithCancel(ctx)
+	var cancel context.CancelFunc
+
+	if o.policy.Timeout > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, o.policy.Timeout, ErrTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
 
+	o.ctx = ctx
 	o.cancel = cancel
 
+	if err := osWrapLimits(o.cmd, o.policy.Limits); err != nil {
+		cancel()
+
+		return fmt.Errorf("cannot apply resource limits: %w", err)
+	}
+
+	if o.ttyFd = osForegroundTTY(o.cmd.Stdin); o.ttyFd >= 0 {
+		o.cmd.SysProcAttr.Foreground = true
+		o.cmd.SysProcAttr.Ctty = o.ttyFd
+	}
+
 	if err := o.cmd.Start(); err != nil {
+		o.restoreForeground()
+		cancel()
+
 		return err
 	}
 
@@ -39,7 +71,13 @@ func (o *osCommand) Start(ctx context.Context) error {
 
 	go osSidecarSignals(ctx, o.waiters, o.cmd)
 
-	go osSidecarGracefulShutdown(ctx, o.waiters, o.cmd)
+	go osSidecarGracefulShutdown(
+		ctx,
+		o.waiters,
+		o.cmd,
+		&o.stopped,
+		o.policy.StopSignal,
+		o.policy.StopGracePeriod)
 
 	return nil
 }
@@ -48,11 +86,18 @@ func (o *osCommand) Wait() ExecutionResult {
 	err := o.cmd.Wait()
 	finishTime := time.Now()
 
+	if !o.policy.KeepOrphans {
+		osKillLeftovers(o.cmd.Process.Pid, o.policy.StopSignal, o.policy.StopGracePeriod)
+	}
+
 	o.cancel()
 	o.waiters.Wait()
+	o.restoreForeground()
 
 	result := ExecutionResult{
 		ElapsedTime: finishTime.Sub(o.startTime),
+		TimedOut:    errors.Is(context.Cause(o.ctx), ErrTimeout),
+		Stopped:     o.stopped.Load(),
 	}
 
 	var exitErr *exec.ExitError
@@ -62,6 +107,20 @@ func (o *osCommand) Wait() ExecutionResult {
 		result.ExitCode = o.cmd.ProcessState.ExitCode()
 		result.UserTime = o.cmd.ProcessState.UserTime()
 		result.SystemTime = o.cmd.ProcessState.SystemTime()
+
+		if status, ok := o.cmd.ProcessState.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			result.Signal = status.Signal()
+		}
+
+		if usage, ok := o.cmd.ProcessState.SysUsage().(*syscall.Rusage); ok {
+			result.MaxRSS = int64(usage.Maxrss) * MaxRSSUnit
+			result.MinorPageFaults = int64(usage.Minflt)
+			result.MajorPageFaults = int64(usage.Majflt)
+			result.BlockInput = int64(usage.Inblock)
+			result.BlockOutput = int64(usage.Oublock)
+			result.VoluntaryContextSwitches = int64(usage.Nvcsw)
+			result.InvoluntaryContextSwitches = int64(usage.Nivcsw)
+		}
 	case errors.As(err, &exitErr):
 		result.ExitCode = exitErr.ExitCode()
 	}
@@ -69,11 +128,18 @@ func (o *osCommand) Wait() ExecutionResult {
 	return result
 }
 
+func (o *osCommand) restoreForeground() {
+	if o.ttyFd >= 0 {
+		osRestoreForeground(o.ttyFd)
+	}
+}
+
 func New(
 	command string,
 	args, environ []string,
 	stdin io.Reader,
 	stdout, stderr io.Writer,
+	policy ExecutionPolicy,
 ) Command {
 	cmd := exec.Command(command, args...)
 
@@ -82,8 +148,31 @@ func New(
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 
+	// a command is a leader of its own process group: this is how
+	// chore signals it with all its descendants.
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	if policy.StopSignal == nil {
+		policy.StopSignal = SignalInterrupt
+	}
+
+	if policy.StopGracePeriod == 0 {
+		policy.StopGracePeriod = StopGracefulPeriod
+	}
+
+	if !policy.KeepOrphans {
+		// leftover processes can hold stdout and stderr pipes. Since
+		// they are going to be killed, it makes no sense to wait for
+		// them.
+		cmd.WaitDelay = OrphansWaitDelay
+	}
+
 	return &osCommand{
 		cmd:     cmd,
+		policy:  policy,
 		waiters: &sync.WaitGroup{},
+		ttyFd:   -1,
 	}
 }
@@ -0,0 +1,220 @@
+//go:build linux
+
+package commands_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/9seconds/chore/internal/commands"
+)
+
+func (suite *OSTestSuite) readPid(path string) int {
+	content, err := os.ReadFile(path)
+	suite.NoError(err)
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	suite.NoError(err)
+
+	return pid
+}
+
+// isAlive tells if a process is alive. Zombies are considered dead: an
+// init process of a container could not reap them.
+func (suite *OSTestSuite) isAlive(pid int) bool {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+
+	fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func (suite *OSTestSuite) TestKillLeftovers() {
+	pidPath := filepath.Join(suite.T().TempDir(), "pid")
+
+	cmd := commands.New(
+		suite.s.Path(),
+		[]string{pidPath},
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			StopGracePeriod: time.Second,
+		})
+
+	suite.EnsureScript("x", "y", `
+sleep 20 &
+echo $! > "$1"`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	result := cmd.Wait()
+	suite.Equal(0, result.ExitCode)
+	suite.Less(result.ElapsedTime, 5*time.Second)
+	suite.False(suite.isAlive(suite.readPid(pidPath)))
+}
+
+func (suite *OSTestSuite) TestKillLeftoversKeepsOtherChildren() {
+	other := exec.Command("sleep", "20")
+
+	suite.NoError(other.Start())
+
+	defer func() {
+		other.Process.Kill() //nolint: errcheck
+		other.Wait()         //nolint: errcheck
+	}()
+
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			StopGracePeriod: time.Second,
+		})
+
+	suite.EnsureScript("x", "y", `sleep 20 >/dev/null 2>&1 &`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	suite.Equal(0, cmd.Wait().ExitCode)
+	suite.True(suite.isAlive(other.Process.Pid))
+}
+
+func (suite *OSTestSuite) TestKillTreeOnTimeout() {
+	pidPath := filepath.Join(suite.T().TempDir(), "pid")
+
+	cmd := commands.New(
+		suite.s.Path(),
+		[]string{pidPath},
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Timeout:         500 * time.Millisecond,
+			StopGracePeriod: time.Second,
+		})
+
+	suite.EnsureScript("x", "y", `
+trap '' TERM
+bash -c 'trap "" TERM; sleep 20' >/dev/null 2>&1 &
+echo $! > "$1"
+wait`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	result := cmd.Wait()
+	suite.True(result.TimedOut)
+	suite.False(suite.isAlive(suite.readPid(pidPath)))
+}
+
+func (suite *OSTestSuite) TestKeepOrphans() {
+	pidPath := filepath.Join(suite.T().TempDir(), "pid")
+
+	cmd := commands.New(
+		suite.s.Path(),
+		[]string{pidPath},
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			KeepOrphans: true,
+		})
+
+	suite.EnsureScript("x", "y", `
+sleep 20 >/dev/null 2>&1 &
+echo $! > "$1"`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	suite.Equal(0, cmd.Wait().ExitCode)
+
+	pid := suite.readPid(pidPath)
+
+	suite.True(suite.isAlive(pid))
+	suite.NoError(syscall.Kill(pid, syscall.SIGKILL))
+
+	syscall.Wait4(pid, nil, 0, nil) //nolint: errcheck
+}
+
+func (suite *OSTestSuite) TestLimits() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Limits: commands.Limits{
+				OpenFiles: 64,
+				FileSize:  1024,
+			},
+		})
+
+	suite.EnsureScript("x", "y", `
+ulimit -n
+sh -c 'ulimit -n'
+ulimit -f
+echo "$@"`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	suite.Equal(0, cmd.Wait().ExitCode)
+	suite.Equal("64\n64\n1\n"+strings.Join(suite.args, " ")+"\n", suite.stdout.String())
+}
+
+func (suite *OSTestSuite) TestLimitsCannotExecute() {
+	path := filepath.Join(suite.T().TempDir(), "script")
+
+	suite.NoError(os.WriteFile(path, []byte("#!/nonexisting/interpreter\n"), 0o700))
+
+	cmd := commands.New(
+		path,
+		nil,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Limits: commands.Limits{
+				OpenFiles: 64,
+			},
+		})
+
+	suite.NoError(cmd.Start(suite.Context()))
+	suite.Equal(commands.LimitsShimExitCode, cmd.Wait().ExitCode)
+	suite.Contains(suite.stderr.String(), "cannot execute command with resource limits")
+}
+
+func (suite *OSTestSuite) TestLimitsSignal() {
+	path := filepath.Join(suite.T().TempDir(), "file")
+
+	cmd := commands.New(
+		suite.s.Path(),
+		[]string{path},
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Limits: commands.Limits{
+				FileSize: 1024,
+			},
+		})
+
+	suite.EnsureScript("x", "y", `exec head -c 4096 /dev/zero > "$1"`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+
+	result := cmd.Wait()
+	suite.Equal(syscall.SIGXFSZ, result.Signal)
+}
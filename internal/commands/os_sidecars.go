@@ -3,11 +3,13 @@
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -40,14 +42,21 @@ func osSidecarSignals(ctx context.Context, waiters *sync.WaitGroup, cmd *exec.Cm
 
 			log.Printf("send %s to %d", sig, cmd.Process.Pid)
 
-			if err := osSendSignal(cmd.Process, sig); err != nil {
+			if err := osSignalGroup(cmd.Process.Pid, sig); err != nil {
 				log.Printf("cannot send %v to process %d: %v", sig, cmd.Process.Pid, err)
 			}
 		}
 	}
 }
 
-func osSidecarGracefulShutdown(ctx context.Context, waiters *sync.WaitGroup, cmd *exec.Cmd) {
+func osSidecarGracefulShutdown(
+	ctx context.Context,
+	waiters *sync.WaitGroup,
+	cmd *exec.Cmd,
+	stopped *atomic.Bool,
+	stopSignal os.Signal,
+	gracePeriod time.Duration,
+) {
 	defer waiters.Done()
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -59,16 +68,22 @@ func osSidecarGracefulShutdown(ctx context.Context, waiters *sync.WaitGroup, cmd
 		return
 	}
 
-	log.Printf("timeout. send interrupt signal")
+	if errors.Is(context.Cause(ctx), ErrTimeout) {
+		log.Printf("execution timeout has fired. send %v to %d", stopSignal, cmd.Process.Pid)
+	} else {
+		log.Printf("context is closed. send %v to %d", stopSignal, cmd.Process.Pid)
+	}
 
 	ticker := time.NewTicker(CheckProcessEvery)
 	defer ticker.Stop()
 
-	gracefulTimer := time.NewTimer(StopGracefulPeriod)
+	gracefulTimer := time.NewTimer(gracePeriod)
 	defer gracefulTimer.Stop()
 
-	if err := osSendSignal(cmd.Process, SignalInterrupt); err != nil {
-		log.Printf("cannot send %v to process %d: %v", SignalInterrupt, cmd.Process.Pid, err)
+	stopped.Store(true)
+
+	if err := osSignalGroup(cmd.Process.Pid, stopSignal); err != nil {
+		log.Printf("cannot send %v to process %d: %v", stopSignal, cmd.Process.Pid, err)
 
 		return
 	}
@@ -86,8 +101,8 @@ func osSidecarGracefulShutdown(ctx context.Context, waiters *sync.WaitGroup, cmd
 
 			log.Printf("graceful period is over. send kill signal")
 
-			if err := osSendSignal(cmd.Process, SignalKill); err != nil {
-				log.Printf("cannot send %v to process %d: %v", SignalInterrupt, cmd.Process.Pid, err)
+			if err := osSignalGroup(cmd.Process.Pid, SignalKill); err != nil {
+				log.Printf("cannot send %v to process %d: %v", SignalKill, cmd.Process.Pid, err)
 			}
 
 			return
@@ -95,6 +110,67 @@ func osSidecarGracefulShutdown(ctx context.Context, waiters *sync.WaitGroup, cmd
 	}
 }
 
+// osKillLeftovers stops processes of the command process group which
+// are still alive after command has exited: background jobs,
+// daemonized children etc. They get stopSignal first and are killed if
+// gracePeriod is not enough.
+func osKillLeftovers(pgid int, stopSignal os.Signal, gracePeriod time.Duration) {
+	if !osIsGroupAlive(pgid) {
+		return
+	}
+
+	log.Printf("command has left processes in group %d. send %v to them", pgid, stopSignal)
+
+	if err := osSignalGroup(pgid, stopSignal); err != nil {
+		log.Printf("cannot send %v to process group %d: %v", stopSignal, pgid, err)
+	}
+
+	ticker := time.NewTicker(CheckProcessEvery)
+	defer ticker.Stop()
+
+	gracefulTimer := time.NewTimer(gracePeriod)
+	defer gracefulTimer.Stop()
+
+	killTimer := time.NewTimer(2 * gracePeriod) //nolint: gomnd
+	defer killTimer.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-gracefulTimer.C:
+			log.Printf("graceful period is over. kill process group %d", pgid)
+
+			if err := osSignalGroup(pgid, SignalKill); err != nil {
+				log.Printf("cannot send %v to process group %d: %v", SignalKill, pgid, err)
+			}
+		case <-killTimer.C:
+			log.Printf("cannot kill leftover processes of group %d", pgid)
+
+			return
+		}
+
+		if !osIsGroupAlive(pgid) {
+			return
+		}
+	}
+}
+
+// osSignalGroup sends a signal to all processes of the process group.
+// A command is started as a leader of its own group so this is the
+// command with all its descendants which have not left the group.
+func osSignalGroup(pgid int, sig os.Signal) error {
+	sysSignal, ok := sig.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("unsupported signal %v", sig)
+	}
+
+	if err := syscall.Kill(-pgid, sysSignal); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+
+	return nil
+}
+
 func osIsProcessAlive(proc *os.Process) bool {
 	err := proc.Signal(syscall.Signal(0))
 
@@ -108,11 +184,3 @@ func osIsProcessAlive(proc *os.Process) bool {
 
 	return true
 }
-
-func osSendSignal(proc *os.Process, sig os.Signal) error {
-	if err := proc.Signal(sig); err != nil && !errors.Is(err, os.ErrProcessDone) {
-		return err
-	}
-
-	return nil
-}
@@ -5,6 +5,7 @@
 	"context"
 	"io"
 	"os"
+	"syscall"
 	"testing"
 	"time"
 
@@ -73,7 +74,8 @@ func (suite *OSTestSuite) TestExecuteCommand() {
 		suite.environ,
 		suite.stdin,
 		suite.stdout,
-		suite.stderr)
+		suite.stderr,
+		commands.ExecutionPolicy{})
 
 	suite.Equal(0, cmd.Pid())
 
@@ -96,7 +98,8 @@ func (suite *OSTestSuite) TestExitCode() {
 		suite.environ,
 		suite.stdin,
 		suite.stdout,
-		suite.stderr)
+		suite.stderr,
+		commands.ExecutionPolicy{})
 
 	suite.EnsureScript("x", "y", "exit 3")
 
@@ -116,13 +119,131 @@ func (suite *OSTestSuite) TestTimeout() {
 		suite.environ,
 		suite.stdin,
 		suite.stdout,
-		suite.stderr)
+		suite.stderr,
+		commands.ExecutionPolicy{})
 
 	suite.EnsureScript("x", "y", "exec sleep 20")
 
 	suite.NoError(cmd.Start(ctx))
 	result := cmd.Wait()
 	suite.Equal(-1, result.ExitCode)
+	suite.True(result.Stopped)
+}
+
+func (suite *OSTestSuite) TestExecutionTimeout() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Timeout: 500 * time.Millisecond,
+		})
+
+	suite.EnsureScript("x", "y", "exec sleep 20")
+
+	suite.NoError(cmd.Start(suite.Context()))
+	result := cmd.Wait()
+	suite.Equal(-1, result.ExitCode)
+	suite.True(result.TimedOut)
+	suite.True(result.Stopped)
+	suite.Less(result.ElapsedTime, 5*time.Second)
+}
+
+func (suite *OSTestSuite) TestStopSignal() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Timeout:         500 * time.Millisecond,
+			StopSignal:      syscall.SIGUSR1,
+			StopGracePeriod: time.Second,
+		})
+
+	suite.EnsureScript("x", "y", `
+trap 'echo usr1; exit 5' USR1
+trap 'echo term; exit 6' TERM
+sleep 20 >/dev/null 2>&1 &
+wait`)
+
+	suite.NoError(cmd.Start(suite.Context()))
+	result := cmd.Wait()
+	suite.Equal(5, result.ExitCode)
+	suite.True(result.TimedOut)
+	suite.Equal("usr1\n", suite.stdout.String())
+}
+
+func (suite *OSTestSuite) TestNoTimeout() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{
+			Timeout: 10 * time.Second,
+		})
+
+	suite.NoError(cmd.Start(suite.Context()))
+	result := cmd.Wait()
+	suite.Equal(0, result.ExitCode)
+	suite.False(result.TimedOut)
+	suite.False(result.Stopped)
+}
+
+func (suite *OSTestSuite) TestResourceUsage() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{})
+
+	suite.NoError(cmd.Start(suite.Context()))
+
+	result := cmd.Wait()
+	suite.Nil(result.Signal)
+	suite.Greater(result.MaxRSS, int64(1024))
+	suite.Greater(result.MinorPageFaults, int64(0))
+	suite.Greater(result.VoluntaryContextSwitches+result.InvoluntaryContextSwitches, int64(0))
+}
+
+func (suite *OSTestSuite) TestTerminatingSignal() {
+	cmd := commands.New(
+		suite.s.Path(),
+		suite.args,
+		suite.environ,
+		suite.stdin,
+		suite.stdout,
+		suite.stderr,
+		commands.ExecutionPolicy{})
+
+	suite.EnsureScript("x", "y", "kill -USR2 $$")
+
+	suite.NoError(cmd.Start(suite.Context()))
+
+	result := cmd.Wait()
+	suite.Equal(syscall.SIGUSR2, result.Signal)
+	suite.Equal(-1, result.ExitCode)
+	suite.False(result.Stopped)
+}
+
+// TestMain makes a test binary work as a limits shim, as chore does.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == commands.LimitsShimCommand {
+		commands.RunLimitsShim(os.Args[2:])
+	}
+
+	os.Exit(m.Run())
 }
 
 func TestOs(t *testing.T) {
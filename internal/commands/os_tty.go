@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// osForegroundTTY returns a descriptor of a terminal if it is stdin of
+// the command and chore is in its foreground process group. Otherwise,
+// -1 is returned.
+//
+// A command runs in its own process group. If it is not a foreground
+// group of the terminal, an attempt to read from it stops the command.
+func osForegroundTTY(stdin io.Reader) int {
+	file, ok := stdin.(*os.File)
+	if !ok {
+		return -1
+	}
+
+	fd := int(file.Fd())
+
+	pgrp, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+	if err != nil || pgrp != unix.Getpgrp() {
+		return -1
+	}
+
+	return fd
+}
+
+// osRestoreForeground makes chore process group a foreground group of
+// the terminal again.
+func osRestoreForeground(fd int) {
+	// chore is in a background group now. It gets SIGTTOU on an
+	// attempt to change a foreground group unless this signal is
+	// ignored.
+	signal.Ignore(syscall.SIGTTOU)
+	defer signal.Reset(syscall.SIGTTOU)
+
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPGRP, unix.Getpgrp()); err != nil {
+		log.Printf("cannot restore foreground process group: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build darwin
+
+package commands
+
+import (
+	"errors"
+	"syscall"
+)
+
+// osIsGroupAlive tells if the process group has processes.
+func osIsGroupAlive(pgid int) bool {
+	err := syscall.Kill(-pgid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
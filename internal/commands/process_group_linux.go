@@ -0,0 +1,63 @@
+//go:build linux
+
+package commands
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// osIsGroupAlive tells if the process group has alive processes.
+// Zombies are skipped: they are going to be reaped by their parents
+// and cannot hold any resources.
+func osIsGroupAlive(pgid int) bool {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		log.Printf("cannot read /proc: %v", err)
+
+		return false
+	}
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		if groupID, state, ok := osReadStat(pid); ok && groupID == pgid && state != "Z" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// osReadStat returns a process group id and a state of the process.
+func osReadStat(pid int) (int, string, bool) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, "", false
+	}
+
+	// format is 'pid (comm) state ppid pgrp ...'. comm can contain
+	// spaces and parentheses so we have to look for the last one.
+	idx := bytes.LastIndexByte(data, ')')
+	if idx < 0 {
+		return 0, "", false
+	}
+
+	fields := bytes.Fields(data[idx+1:])
+	if len(fields) < 3 { //nolint: gomnd
+		return 0, "", false
+	}
+
+	groupID, err := strconv.Atoi(string(fields[2]))
+	if err != nil {
+		return 0, "", false
+	}
+
+	return groupID, string(fields[0]), true
+}
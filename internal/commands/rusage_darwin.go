@@ -0,0 +1,7 @@
+//go:build darwin
+
+package commands
+
+// MaxRSSUnit is a size of ru_maxrss unit in bytes. Darwin reports it
+// in bytes.
+const MaxRSSUnit = 1
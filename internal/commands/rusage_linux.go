@@ -0,0 +1,7 @@
+//go:build linux
+
+package commands
+
+// MaxRSSUnit is a size of ru_maxrss unit in bytes. Linux reports it
+// in kilobytes.
+const MaxRSSUnit = 1024
@@ -0,0 +1,32 @@
+package commands_test
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/9seconds/chore/internal/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSignal(t *testing.T) {
+	testTable := map[string]syscall.Signal{
+		"SIGTERM": syscall.SIGTERM,
+		"TERM":    syscall.SIGTERM,
+		"sigint":  syscall.SIGINT,
+		" hup ":   syscall.SIGHUP,
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		t.Run(testValue, func(t *testing.T) {
+			sig, err := commands.ParseSignal(testValue)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, sig)
+		})
+	}
+
+	_, err := commands.ParseSignal("SIGXXX")
+	assert.ErrorContains(t, err, "unknown signal")
+}
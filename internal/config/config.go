@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -14,7 +15,27 @@
 
 type Config struct {
 	Env   map[string]map[string]string `toml:"env"`
-	Vault map[string]string            `toml:"vault"`
+	Vault map[string]VaultPassword     `toml:"vault"`
+}
+
+// GetVaultPassword returns a password of a namespace vault.
+func (c Config) GetVaultPassword(
+	ctx context.Context,
+	namespace string,
+	input io.Reader,
+	output io.Writer,
+) (string, error) {
+	password, ok := c.Vault[namespace]
+	if !ok {
+		return "", fmt.Errorf("cannot find out correct password for namespace %s", namespace)
+	}
+
+	value, err := password.Resolve(ctx, input, output)
+	if err != nil {
+		return "", fmt.Errorf("cannot get password for namespace %s: %w", namespace, err)
+	}
+
+	return value, nil
 }
 
 func (c Config) Environ(namespace string) []string {
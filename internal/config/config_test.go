@@ -55,7 +55,10 @@ func (suite *ConfigTestSuite) TestOk() {
 
 	conf, err := config.ReadConfig(reader)
 	suite.NoError(err)
-	suite.Equal(conf.Vault, map[string]string{"y": "1", "z": "2"})
+	suite.Equal(conf.Vault, map[string]config.VaultPassword{
+		"y": {Password: "1"},
+		"z": {Password: "2"},
+	})
 	suite.Equal(conf.Env, map[string]map[string]string{"x": {"y": "1"}})
 }
 
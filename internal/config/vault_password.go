@@ -0,0 +1,175 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/9seconds/chore/internal/prompt"
+	"github.com/anmitsu/go-shlex"
+)
+
+// VaultPasswordFilePermissionMask defines permission bits which must not
+// be set for a password file.
+const VaultPasswordFilePermissionMask = 0o077
+
+var (
+	ErrVaultPasswordSource = errors.New("exactly one password source has to be defined")
+	ErrVaultPasswordEmpty  = errors.New("password is empty")
+	ErrNotInteractive      = errors.New("cannot ask for a password: not a terminal")
+)
+
+// VaultPassword defines where a password of a namespace vault comes
+// from. In config, it is either a string (a password itself) or a table
+// with exactly one of password_command, password_env, password_file or
+// prompt keys.
+type VaultPassword struct {
+	Password string
+	Command  string
+	Env      string
+	File     string
+	Prompt   bool
+}
+
+func (v *VaultPassword) UnmarshalTOML(data interface{}) error { //nolint: cyclop
+	switch value := data.(type) {
+	case string:
+		*v = VaultPassword{Password: value}
+
+		return nil
+	case map[string]interface{}:
+	default:
+		return fmt.Errorf("unexpected password definition %v", data)
+	}
+
+	password := VaultPassword{}
+	sources := 0
+
+	for key, value := range data.(map[string]interface{}) {
+		var ok bool
+
+		switch key {
+		case "password_command":
+			password.Command, ok = value.(string)
+		case "password_env":
+			password.Env, ok = value.(string)
+		case "password_file":
+			password.File, ok = value.(string)
+		case "prompt":
+			password.Prompt, ok = value.(bool)
+		default:
+			return fmt.Errorf("unknown key %s", key)
+		}
+
+		if !ok {
+			return fmt.Errorf("incorrect type of %s", key)
+		}
+
+		sources++
+	}
+
+	if sources != 1 || !(password.Command != "" || password.Env != "" || password.File != "" || password.Prompt) {
+		return ErrVaultPasswordSource
+	}
+
+	*v = password
+
+	return nil
+}
+
+// IsStatic tells if a password could be resolved without running
+// external commands or asking a user.
+func (v VaultPassword) IsStatic() bool {
+	return v.Command == "" && !v.Prompt
+}
+
+// Resolve returns a password. Input and output are used only if user
+// has to be asked for a password.
+func (v VaultPassword) Resolve(ctx context.Context, input io.Reader, output io.Writer) (string, error) {
+	switch {
+	case v.Command != "":
+		return v.resolveCommand(ctx, input)
+	case v.Env != "":
+		value, ok := os.LookupEnv(v.Env)
+		if !ok || value == "" {
+			return "", fmt.Errorf("environment variable %s: %w", v.Env, ErrVaultPasswordEmpty)
+		}
+
+		return value, nil
+	case v.File != "":
+		return v.resolveFile()
+	case v.Prompt:
+		if !prompt.IsInteractive(input) {
+			return "", ErrNotInteractive
+		}
+
+		return prompt.New(input, output).Ask("Vault password", true, nil)
+	}
+
+	return v.Password, nil
+}
+
+func (v VaultPassword) resolveCommand(ctx context.Context, input io.Reader) (string, error) {
+	args, err := shlex.Split(v.Command, true)
+
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("cannot parse password command: %w", err)
+	case len(args) == 0:
+		return "", fmt.Errorf("password command is empty")
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+
+	// a command must not consume data which is piped to chore. It gets
+	// an input only if it is a terminal so command could ask a user.
+	if prompt.IsInteractive(input) {
+		cmd.Stdin = input
+	}
+
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("cannot execute password command: %w", err)
+	}
+
+	value := strings.TrimRight(stdout.String(), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("password command: %w", ErrVaultPasswordEmpty)
+	}
+
+	return value, nil
+}
+
+func (v VaultPassword) resolveFile() (string, error) {
+	stat, err := os.Stat(v.File)
+	if err != nil {
+		return "", fmt.Errorf("cannot stat password file: %w", err)
+	}
+
+	if stat.Mode().Perm()&VaultPasswordFilePermissionMask != 0 {
+		return "", fmt.Errorf(
+			"password file %s must not be accessible by group or others, has %v",
+			v.File,
+			stat.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(v.File)
+	if err != nil {
+		return "", fmt.Errorf("cannot read password file: %w", err)
+	}
+
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("password file %s: %w", v.File, ErrVaultPasswordEmpty)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,151 @@
+package config_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/9seconds/chore/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type VaultPasswordTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VaultPasswordTestSuite) parse(data string) (config.VaultPassword, error) {
+	conf, err := config.ReadConfig(strings.NewReader("[vault]\n" + data))
+	if err != nil {
+		return config.VaultPassword{}, err
+	}
+
+	return conf.Vault["ns"], nil
+}
+
+func (suite *VaultPasswordTestSuite) resolve(password config.VaultPassword) (string, error) {
+	return password.Resolve(context.Background(), strings.NewReader(""), io.Discard)
+}
+
+func (suite *VaultPasswordTestSuite) TestParse() {
+	testTable := map[string]config.VaultPassword{
+		`ns = "xxx"`:                          {Password: "xxx"},
+		`ns = { password_command = "echo" }`:  {Command: "echo"},
+		`ns = { password_env = "X" }`:         {Env: "X"},
+		`ns = { password_file = "/tmp/xxx" }`: {File: "/tmp/xxx"},
+		`ns = { prompt = true }`:              {Prompt: true},
+	}
+
+	for testName, expected := range testTable {
+		testName := testName
+		expected := expected
+
+		suite.T().Run(testName, func(t *testing.T) {
+			password, err := suite.parse(testName)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, password)
+		})
+	}
+}
+
+func (suite *VaultPasswordTestSuite) TestParseIncorrect() {
+	testTable := []string{
+		`ns = 1`,
+		`ns = {}`,
+		`ns = { prompt = false }`,
+		`ns = { password_env = 1 }`,
+		`ns = { password = "x" }`,
+		`ns = { password_env = "X", password_file = "/tmp/xxx" }`,
+	}
+
+	for _, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := suite.parse(testValue)
+			assert.ErrorContains(t, err, "cannot parse TOML config")
+		})
+	}
+}
+
+func (suite *VaultPasswordTestSuite) TestCommand() {
+	value, err := suite.resolve(config.VaultPassword{Command: "echo 'pass word'"})
+	suite.NoError(err)
+	suite.Equal("pass word", value)
+
+	_, err = suite.resolve(config.VaultPassword{Command: "false"})
+	suite.ErrorContains(err, "cannot execute password command")
+
+	_, err = suite.resolve(config.VaultPassword{Command: "true"})
+	suite.ErrorIs(err, config.ErrVaultPasswordEmpty)
+}
+
+func (suite *VaultPasswordTestSuite) TestCommandDoesNotReadInput() {
+	value, err := config.VaultPassword{Command: "sh -c 'cat; echo pass'"}.Resolve(
+		context.Background(),
+		strings.NewReader("script data\n"),
+		io.Discard)
+	suite.NoError(err)
+	suite.Equal("pass", value)
+}
+
+func (suite *VaultPasswordTestSuite) TestIsStatic() {
+	suite.True(config.VaultPassword{Password: "x"}.IsStatic())
+	suite.True(config.VaultPassword{Env: "X"}.IsStatic())
+	suite.True(config.VaultPassword{File: "/tmp/x"}.IsStatic())
+	suite.False(config.VaultPassword{Command: "echo"}.IsStatic())
+	suite.False(config.VaultPassword{Prompt: true}.IsStatic())
+}
+
+func (suite *VaultPasswordTestSuite) TestEnv() {
+	suite.T().Setenv("CHORE_TEST_VAULT_PASSWORD", "xxx")
+
+	value, err := suite.resolve(config.VaultPassword{Env: "CHORE_TEST_VAULT_PASSWORD"})
+	suite.NoError(err)
+	suite.Equal("xxx", value)
+
+	_, err = suite.resolve(config.VaultPassword{Env: "CHORE_TEST_VAULT_PASSWORD_UNKNOWN"})
+	suite.ErrorIs(err, config.ErrVaultPasswordEmpty)
+}
+
+func (suite *VaultPasswordTestSuite) TestFile() {
+	path := filepath.Join(suite.T().TempDir(), "password")
+
+	suite.NoError(os.WriteFile(path, []byte("xxx\n"), 0o600))
+
+	value, err := suite.resolve(config.VaultPassword{File: path})
+	suite.NoError(err)
+	suite.Equal("xxx", value)
+
+	suite.NoError(os.Chmod(path, 0o644))
+
+	_, err = suite.resolve(config.VaultPassword{File: path})
+	suite.ErrorContains(err, "must not be accessible")
+}
+
+func (suite *VaultPasswordTestSuite) TestPromptNotInteractive() {
+	_, err := suite.resolve(config.VaultPassword{Prompt: true})
+	suite.ErrorIs(err, config.ErrNotInteractive)
+}
+
+func (suite *VaultPasswordTestSuite) TestGetVaultPassword() {
+	conf := config.Config{
+		Vault: map[string]config.VaultPassword{
+			"ns": {Password: "xxx"},
+		},
+	}
+
+	value, err := conf.GetVaultPassword(context.Background(), "ns", nil, io.Discard)
+	suite.NoError(err)
+	suite.Equal("xxx", value)
+
+	_, err = conf.GetVaultPassword(context.Background(), "xx", nil, io.Discard)
+	suite.ErrorContains(err, "cannot find out correct password")
+}
+
+func TestVaultPassword(t *testing.T) {
+	suite.Run(t, &VaultPasswordTestSuite{})
+}
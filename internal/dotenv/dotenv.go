@@ -0,0 +1,334 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const exportPrefix = "export"
+
+var (
+	ErrIncorrectName     = errors.New("incorrect variable name")
+	ErrNoSeparator       = errors.New("no '=' after a variable name")
+	ErrUnterminatedQuote = errors.New("unterminated quoted value")
+	ErrTrailingData      = errors.New("unexpected data after a quoted value")
+
+	nameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// Lookup returns a value of the variable which is defined outside of
+// dotenv file. It is used to expand ${VAR} references.
+type Lookup func(name string) (string, bool)
+
+type parser struct {
+	data   string
+	pos    int
+	line   int
+	lookup Lookup
+	values map[string]string
+}
+
+// Parse parses dotenv data into a list of KEY=VALUE values. Values are
+// returned in order of their definition.
+//
+// Supported syntax: comments starting with #, optional export prefix,
+// single-quoted literal values, double-quoted values with escape
+// sequences and unquoted values. Quoted values can span multiple lines.
+// ${VAR} and $VAR references are expanded in double-quoted and unquoted
+// values; variables defined earlier in the same file take precedence
+// over lookup.
+func Parse(reader io.Reader, lookup Lookup) ([]string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read data: %w", err)
+	}
+
+	prs := &parser{
+		data:   strings.ReplaceAll(string(data), "\r\n", "\n"),
+		line:   1,
+		lookup: lookup,
+		values: map[string]string{},
+	}
+
+	environ := []string{}
+
+	for {
+		prs.skipBlank()
+
+		if prs.eof() {
+			return environ, nil
+		}
+
+		line := prs.line
+
+		name, value, err := prs.parseEntry()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		prs.values[name] = value
+		environ = append(environ, name+"="+value)
+	}
+}
+
+// Load parses a dotenv file. If file does not exist, nil is returned.
+func Load(path string, lookup Lookup) ([]string, error) {
+	file, err := os.Open(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot open file: %w", err)
+	}
+
+	defer file.Close()
+
+	return Parse(file, lookup)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	return p.data[p.pos]
+}
+
+func (p *parser) advance() byte {
+	char := p.data[p.pos]
+
+	p.pos++
+
+	if char == '\n' {
+		p.line++
+	}
+
+	return char
+}
+
+// skipBlank skips whitespaces, empty lines and comments.
+func (p *parser) skipBlank() {
+	for !p.eof() {
+		switch char := p.peek(); {
+		case char == '#':
+			p.skipLine()
+		case unicode.IsSpace(rune(char)):
+			p.advance()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.advance()
+	}
+}
+
+func (p *parser) skipLine() {
+	for !p.eof() && p.advance() != '\n' {
+	}
+}
+
+func (p *parser) parseEntry() (string, string, error) {
+	name := nameRegexp.FindString(p.data[p.pos:])
+	if name == "" {
+		return "", "", ErrIncorrectName
+	}
+
+	p.pos += len(name)
+
+	if name == exportPrefix && !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+
+		name = nameRegexp.FindString(p.data[p.pos:])
+		if name == "" {
+			return "", "", ErrIncorrectName
+		}
+
+		p.pos += len(name)
+	}
+
+	p.skipSpaces()
+
+	if p.eof() || p.peek() != '=' {
+		return "", "", fmt.Errorf("%s: %w", name, ErrNoSeparator)
+	}
+
+	p.advance()
+	p.skipSpaces()
+
+	var (
+		value string
+		err   error
+	)
+
+	switch {
+	case p.eof():
+	case p.peek() == '\'':
+		value, err = p.parseSingleQuoted()
+	case p.peek() == '"':
+		value, err = p.parseDoubleQuoted()
+	default:
+		value = p.parseUnquoted()
+	}
+
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	return name, value, nil
+}
+
+func (p *parser) parseSingleQuoted() (string, error) {
+	p.advance()
+
+	end := strings.IndexByte(p.data[p.pos:], '\'')
+	if end < 0 {
+		return "", ErrUnterminatedQuote
+	}
+
+	value := p.data[p.pos : p.pos+end]
+
+	for i := 0; i <= end; i++ {
+		p.advance()
+	}
+
+	return value, p.finishQuoted()
+}
+
+func (p *parser) parseDoubleQuoted() (string, error) {
+	p.advance()
+
+	buf := &strings.Builder{}
+
+	for {
+		if p.eof() {
+			return "", ErrUnterminatedQuote
+		}
+
+		switch char := p.advance(); char {
+		case '"':
+			return buf.String(), p.finishQuoted()
+		case '\\':
+			if p.eof() {
+				return "", ErrUnterminatedQuote
+			}
+
+			switch escaped := p.advance(); escaped {
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case '"', '\\', '$':
+				buf.WriteByte(escaped)
+			default:
+				buf.WriteByte('\\')
+				buf.WriteByte(escaped)
+			}
+		case '$':
+			buf.WriteString(p.parseReference())
+		default:
+			buf.WriteByte(char)
+		}
+	}
+}
+
+// finishQuoted makes sure that only spaces and a comment follow a
+// closing quote.
+func (p *parser) finishQuoted() error {
+	p.skipSpaces()
+
+	switch {
+	case p.eof():
+	case p.peek() == '#':
+		p.skipLine()
+	case p.peek() == '\n':
+		p.advance()
+	default:
+		return ErrTrailingData
+	}
+
+	return nil
+}
+
+func (p *parser) parseUnquoted() string {
+	buf := &strings.Builder{}
+
+	for !p.eof() {
+		char := p.peek()
+
+		switch {
+		case char == '\n':
+			p.advance()
+
+			return strings.TrimRightFunc(buf.String(), unicode.IsSpace)
+		case char == '#' && (p.data[p.pos-1] == ' ' || p.data[p.pos-1] == '\t'):
+			p.skipLine()
+
+			return strings.TrimRightFunc(buf.String(), unicode.IsSpace)
+		case char == '$':
+			p.advance()
+			buf.WriteString(p.parseReference())
+		default:
+			buf.WriteByte(p.advance())
+		}
+	}
+
+	return strings.TrimRightFunc(buf.String(), unicode.IsSpace)
+}
+
+// parseReference expands a reference after $. If there is no valid
+// reference, $ is kept as is.
+func (p *parser) parseReference() string {
+	if !p.eof() && p.peek() == '{' {
+		end := strings.IndexByte(p.data[p.pos:], '}')
+		if end < 0 {
+			return "$"
+		}
+
+		name := p.data[p.pos+1 : p.pos+end]
+		if name == "" || nameRegexp.FindString(name) != name {
+			return "$"
+		}
+
+		for i := 0; i <= end; i++ {
+			p.advance()
+		}
+
+		return p.resolve(name)
+	}
+
+	name := nameRegexp.FindString(p.data[p.pos:])
+	if name == "" {
+		return "$"
+	}
+
+	p.pos += len(name)
+
+	return p.resolve(name)
+}
+
+func (p *parser) resolve(name string) string {
+	if value, ok := p.values[name]; ok {
+		return value
+	}
+
+	if p.lookup != nil {
+		if value, ok := p.lookup(name); ok {
+			return value
+		}
+	}
+
+	return ""
+}
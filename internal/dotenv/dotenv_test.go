@@ -0,0 +1,98 @@
+package dotenv_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/9seconds/chore/internal/dotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DotenvTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DotenvTestSuite) Lookup(name string) (string, bool) {
+	if name == "OUTER" {
+		return "outer", true
+	}
+
+	return "", false
+}
+
+func (suite *DotenvTestSuite) TestParse() {
+	data := `
+# comment
+A=1
+export B = 2  # inline comment
+C='single $A # not a comment'
+D="double $A ${B}\t\"quoted\" \$A"
+E="multi
+line"
+F='multi
+line too'
+G=${OUTER}/$A/${UNKNOWN}/$
+H=
+I=#not-a-comment
+J=${A}${A}
+A=redefined
+K=$A
+`
+
+	environ, err := dotenv.Parse(strings.NewReader(data), suite.Lookup)
+	suite.NoError(err)
+	suite.Equal([]string{
+		"A=1",
+		"B=2",
+		"C=single $A # not a comment",
+		"D=double 1 2\t\"quoted\" $A",
+		"E=multi\nline",
+		"F=multi\nline too",
+		"G=outer/1//$",
+		"H=",
+		"I=#not-a-comment",
+		"J=11",
+		"A=redefined",
+		"K=redefined",
+	}, environ)
+}
+
+func (suite *DotenvTestSuite) TestParseErrors() {
+	testTable := map[string]string{
+		"incorrect name":      "1A=1",
+		"no separator":        "A 1",
+		"unterminated single": "A='1",
+		"unterminated double": `A="1`,
+		"trailing data":       `A="1" 2`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := dotenv.Parse(strings.NewReader("\n"+testValue), nil)
+			assert.ErrorContains(t, err, "line 2")
+		})
+	}
+}
+
+func (suite *DotenvTestSuite) TestLoad() {
+	path := filepath.Join(suite.T().TempDir(), ".env")
+
+	environ, err := dotenv.Load(path, nil)
+	suite.NoError(err)
+	suite.Nil(environ)
+
+	suite.NoError(os.WriteFile(path, []byte("A=1\r\nB=2"), 0o600))
+
+	environ, err = dotenv.Load(path, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"A=1", "B=2"}, environ)
+}
+
+func TestDotenv(t *testing.T) {
+	suite.Run(t, &DotenvTestSuite{})
+}
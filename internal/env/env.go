@@ -7,6 +7,7 @@
 	ParameterPrefix     = Prefix + "P_"
 	ParameterPrefixList = Prefix + "PL_"
 	FlagPrefix          = Prefix + "F_"
+	ArgPrefix           = Prefix + "ARG_"
 	PathPrefix          = Prefix + "PATH_"
 	NetworkPrefix       = Prefix + "NETWORK_"
 	StartedAtPrefix     = Prefix + "STARTED_AT_"
@@ -26,6 +27,8 @@
 	Self      = Prefix + "SELF"
 	Slug      = Prefix + "SLUG"
 
+	RetryAttempt = Prefix + "RETRY_ATTEMPT"
+
 	PathCaller = PathPrefix + "CALLER"
 	PathData   = PathPrefix + "DATA"
 	PathCache  = PathPrefix + "CACHE"
@@ -97,6 +100,10 @@ func ParameterNameList(name string) string {
 	return ParameterPrefixList + strings.ToUpper(name)
 }
 
+func ArgName(name string) string {
+	return ArgPrefix + strings.ToUpper(name)
+}
+
 func FlagName(name string) string {
 	return FlagPrefix + strings.ToUpper(name)
 }
@@ -0,0 +1,118 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/9seconds/chore/internal/argparse"
+)
+
+const (
+	SourceParent     = "parent"
+	SourceConfig     = "config"
+	SourceScript     = "script"
+	SourceParameter  = "parameter"
+	SourceFlag       = "flag"
+	SourcePositional = "positional"
+	SourceRun        = "run"
+	SourceSecret     = "secret"
+	SourceSecretFile = "secret_file"
+
+	SourceGeneratorPrefix = "generator:"
+	SourceDotenvPrefix    = "dotenv:"
+
+	// MaskedValue replaces values of secret variables where they are
+	// shown to user.
+	MaskedValue = argparse.MaskedValue
+)
+
+// Variable is an environment variable with a source it came from.
+type Variable struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+
+	// Sensitive tells that a value carries sensitive arguments of
+	// the script.
+	Sensitive bool `json:"-"`
+}
+
+func (v Variable) String() string {
+	return MakeValue(v.Name, v.Value)
+}
+
+// Masked returns a copy of the variable which is safe to show: values
+// of secrets and sensitive variables are replaced with MaskedValue.
+func (v Variable) Masked() Variable {
+	if v.Source == SourceSecret || v.Sensitive {
+		v.Value = MaskedValue
+	}
+
+	return v
+}
+
+// Skip describes why generator has not produced some variables.
+type Skip struct {
+	Generator string `json:"generator"`
+	Reason    string `json:"reason"`
+}
+
+type skipReporterKey struct{}
+
+// SkipReporter is a callback which receives reasons why generator has
+// skipped some values.
+type SkipReporter func(reason string)
+
+func WithSkipReporter(ctx context.Context, reporter SkipReporter) context.Context {
+	return context.WithValue(ctx, skipReporterKey{}, reporter)
+}
+
+func SourceGenerator(name string) string {
+	return SourceGeneratorPrefix + name
+}
+
+func SourceDotenv(path string) string {
+	return SourceDotenvPrefix + path
+}
+
+// MakeVariables converts a list of environment values in KEY=VALUE
+// format into variables with a given source.
+func MakeVariables(environ []string, source string) []Variable {
+	vars := make([]Variable, 0, len(environ))
+
+	for _, value := range environ {
+		name, value, _ := strings.Cut(value, "=")
+
+		vars = append(vars, Variable{
+			Name:   name,
+			Value:  value,
+			Source: source,
+		})
+	}
+
+	return vars
+}
+
+// FromVariables converts variables into a list of environment values in
+// KEY=VALUE format.
+func FromVariables(vars []Variable) []string {
+	environ := make([]string, 0, len(vars))
+
+	for _, v := range vars {
+		environ = append(environ, v.String())
+	}
+
+	return environ
+}
+
+func reportSkip(ctx context.Context, format string, args ...interface{}) {
+	reason := fmt.Sprintf(format, args...)
+
+	log.Print(reason)
+
+	if reporter, ok := ctx.Value(skipReporterKey{}).(SkipReporter); ok {
+		reporter(reason)
+	}
+}
@@ -0,0 +1,55 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/9seconds/chore/internal/env"
+	"github.com/9seconds/chore/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExplainTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *ExplainTestSuite) TestSkipReporter() {
+	reasons := []string{}
+	ctx := env.WithSkipReporter(suite.Context(), func(reason string) {
+		reasons = append(reasons, reason)
+	})
+
+	env.GenerateGit(ctx, suite.values, suite.wg, git.AccessModeNo)
+	suite.Empty(suite.Collect())
+	suite.Equal([]string{"git access mode is no"}, reasons)
+}
+
+func TestMakeVariables(t *testing.T) {
+	vars := env.MakeVariables([]string{"A=1", "B=", "C=x=y"}, env.SourceParent)
+
+	assert.Equal(t, []env.Variable{
+		{Name: "A", Value: "1", Source: env.SourceParent},
+		{Name: "B", Value: "", Source: env.SourceParent},
+		{Name: "C", Value: "x=y", Source: env.SourceParent},
+	}, vars)
+	assert.Equal(t, []string{"A=1", "B=", "C=x=y"}, env.FromVariables(vars))
+}
+
+func TestMaskedVariable(t *testing.T) {
+	secret := env.Variable{Name: "A", Value: "1", Source: env.SourceSecret}
+	sensitive := env.Variable{Name: "A", Value: "1", Source: env.SourceParameter, Sensitive: true}
+	plain := env.Variable{Name: "A", Value: "1", Source: env.SourceScript}
+
+	assert.Equal(t, "A="+env.MaskedValue, secret.Masked().String())
+	assert.Equal(t, "1", secret.Value)
+	assert.Equal(t, "A="+env.MaskedValue, sensitive.Masked().String())
+	assert.Equal(t, plain, plain.Masked())
+}
+
+func TestSourceGenerator(t *testing.T) {
+	assert.Equal(t, "generator:git", env.SourceGenerator("git"))
+}
+
+func TestExplain(t *testing.T) {
+	suite.Run(t, &ExplainTestSuite{})
+}
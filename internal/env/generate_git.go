@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"strconv"
 	"sync"
@@ -15,9 +14,13 @@
 func GenerateGit(ctx context.Context, results chan<- string, waiters *sync.WaitGroup, mode git.AccessMode) { //nolint: cyclop
 	switch mode {
 	case git.AccessModeNo:
+		reportSkip(ctx, "git access mode is %s", mode)
+
 		return
 	case git.AccessModeIfUndefined:
 		if _, ok := os.LookupEnv(GitReference); ok {
+			reportSkip(ctx, "git access mode is %s and %s is already defined", mode, GitReference)
+
 			return
 		}
 	}
@@ -29,14 +32,14 @@ func GenerateGit(ctx context.Context, results chan<- string, waiters *sync.WaitG
 
 		repo, err := git.Get()
 		if err != nil {
-			log.Printf("cannot find out correct git repo: %v", err)
+			reportSkip(ctx, "cannot find out correct git repo: %v", err)
 
 			return
 		}
 
 		head, err := repo.Head()
 		if err != nil {
-			log.Printf("cannot lookup HEAD: %v", err)
+			reportSkip(ctx, "cannot lookup HEAD: %v", err)
 
 			return
 		}
@@ -64,7 +67,7 @@ func GenerateGit(ctx context.Context, results chan<- string, waiters *sync.WaitG
 		}
 
 		if isDirty, err := repo.IsDirty(); err != nil {
-			log.Printf("cannot detect if repository is dirty: %v", err)
+			reportSkip(ctx, "cannot detect if repository is dirty: %v", err)
 		} else {
 			sendValue(ctx, results, GitIsDirty, strconv.FormatBool(isDirty))
 		}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"sync"
 
@@ -15,19 +14,23 @@ func GenerateHostname(ctx context.Context, results chan<- string, waiters *sync.
 	go func() {
 		defer waiters.Done()
 
-		if _, ok := os.LookupEnv(Hostname); !ok {
+		if _, ok := os.LookupEnv(Hostname); ok {
+			reportSkip(ctx, "%s is already defined", Hostname)
+		} else {
 			if value, err := os.Hostname(); err == nil {
 				sendValue(ctx, results, Hostname, value)
 			} else {
-				log.Printf("cannot get hostname: %v", err)
+				reportSkip(ctx, "cannot get hostname: %v", err)
 			}
 		}
 
-		if _, ok := os.LookupEnv(HostnameFQDN); !ok {
+		if _, ok := os.LookupEnv(HostnameFQDN); ok {
+			reportSkip(ctx, "%s is already defined", HostnameFQDN)
+		} else {
 			if value, err := fqdn.FqdnHostname(); err == nil {
 				sendValue(ctx, results, HostnameFQDN, value)
 			} else {
-				log.Printf("cannot get fqdn hostname: %v", err)
+				reportSkip(ctx, "cannot get fqdn hostname: %v", err)
 			}
 		}
 	}()
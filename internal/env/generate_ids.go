@@ -26,12 +26,17 @@ func GenerateIds(
 			chainRun = binutils.NewID()
 		}
 
-		checksum := args.Checksum()
-		isolatedID := binutils.Chain(scriptID, checksum)
-		chainedIsolatedID := binutils.Chain(os.Getenv(IDChainIsolated), scriptID, checksum)
+		isolatedID := IsolatedID(scriptID, args)
+		chainedIsolatedID := binutils.Chain(os.Getenv(IDChainIsolated), scriptID, args.Checksum())
 
 		sendValue(ctx, results, IDChainRun, chainRun)
 		sendValue(ctx, results, IDIsolated, isolatedID)
 		sendValue(ctx, results, IDChainIsolated, chainedIsolatedID)
 	}()
 }
+
+// IsolatedID returns an ID which is the same for the same script
+// executed with the same arguments.
+func IsolatedID(scriptID string, args argparse.ParsedArgs) string {
+	return binutils.Chain(scriptID, args.Checksum())
+}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"sync"
 
@@ -11,6 +10,8 @@
 
 func GenerateMachineID(ctx context.Context, results chan<- string, waiters *sync.WaitGroup) {
 	if _, ok := os.LookupEnv(MachineID); ok {
+		reportSkip(ctx, "%s is already defined", MachineID)
+
 		return
 	}
 
@@ -20,12 +21,14 @@ func GenerateMachineID(ctx context.Context, results chan<- string, waiters *sync
 		defer waiters.Done()
 
 		if _, ok := os.LookupEnv(MachineID); ok {
+			reportSkip(ctx, "%s is already defined", MachineID)
+
 			return
 		}
 
 		value, err := machineid.ProtectedID("chore")
 		if err != nil {
-			log.Printf("cannot obtain machine id: %v", err)
+			reportSkip(ctx, "cannot obtain machine id: %v", err)
 
 			return
 		}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"regexp"
 	"strings"
@@ -36,6 +35,8 @@ func GenerateNetwork(
 	requireNetwork bool,
 ) {
 	if !requireNetwork {
+		reportSkip(ctx, "network access is disabled")
+
 		return
 	}
 
@@ -45,6 +46,8 @@ func GenerateNetwork(
 		defer waiters.Done()
 
 		if _, ok := os.LookupEnv(NetworkIPv4); ok {
+			reportSkip(ctx, "%s is already defined", NetworkIPv4)
+
 			return
 		}
 
@@ -56,7 +59,7 @@ func GenerateNetwork(
 			"https://ipinfo.io/json",
 			&resp)
 		if err != nil {
-			log.Printf("cannot request network data: %v", err)
+			reportSkip(ctx, "cannot request network data: %v", err)
 
 			return
 		}
@@ -93,6 +96,8 @@ func GenerateNetworkIPv6(
 	requireNetwork bool,
 ) {
 	if !requireNetwork {
+		reportSkip(ctx, "network access is disabled")
+
 		return
 	}
 
@@ -102,6 +107,8 @@ func GenerateNetworkIPv6(
 		defer waiters.Done()
 
 		if _, ok := os.LookupEnv(NetworkIPv6); ok {
+			reportSkip(ctx, "%s is already defined", NetworkIPv6)
+
 			return
 		}
 
@@ -113,7 +120,7 @@ func GenerateNetworkIPv6(
 			"https://ifconfig.co",
 			&resp)
 		if err != nil {
-			log.Printf("cannot get IPv6 address: %v", err)
+			reportSkip(ctx, "cannot get IPv6 address: %v", err)
 
 			return
 		}
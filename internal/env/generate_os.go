@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"runtime"
 	"strconv"
@@ -21,12 +20,14 @@ func GenerateOS(ctx context.Context, results chan<- string, waiters *sync.WaitGr
 		sendValue(ctx, results, OSArch, runtime.GOARCH)
 
 		if _, ok := os.LookupEnv(OSID); ok {
+			reportSkip(ctx, "%s is already defined", OSID)
+
 			return
 		}
 
 		version, err := osversion.Get()
 		if err != nil {
-			log.Printf("cannot get os version: %v", err)
+			reportSkip(ctx, "cannot get os version: %v", err)
 
 			return
 		}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"sync"
 
@@ -24,7 +23,7 @@ func GenerateSelf(
 
 		executable, err := os.Executable()
 		if err != nil {
-			log.Printf("cannot find out current executable: %v", err)
+			reportSkip(ctx, "cannot find out current executable: %v", err)
 
 			return
 		}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"strconv"
 	"sync"
 	"time"
@@ -50,7 +49,7 @@ func GenerateTime(ctx context.Context, results chan<- string, wg *sync.WaitGroup
 			StartedAtWeekdayStr, utc.Weekday().String())
 
 		if tzname, err := tzlocal.RuntimeTZ(); err != nil {
-			log.Printf("cannot obtain runtime timezone information: %v", err)
+			reportSkip(ctx, "cannot obtain runtime timezone information: %v", err)
 		} else {
 			sendValue(ctx, results, StartedAtTimezone, tzname)
 		}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"log"
 	"os"
 	"os/user"
 	"sync"
@@ -10,6 +9,8 @@
 
 func GenerateUser(ctx context.Context, results chan<- string, waiters *sync.WaitGroup) {
 	if _, ok := os.LookupEnv(UserName); ok {
+		reportSkip(ctx, "%s is already defined", UserName)
+
 		return
 	}
 
@@ -20,7 +21,7 @@ func GenerateUser(ctx context.Context, results chan<- string, waiters *sync.Wait
 
 		user, err := user.Current()
 		if err != nil {
-			log.Printf("cannot get current user: %v", err)
+			reportSkip(ctx, "cannot get current user: %v", err)
 
 			return
 		}
@@ -4,9 +4,10 @@
 	"context"
 	"os"
 	"regexp"
+	"strings"
 )
 
-var environCleanupRegexp = regexp.MustCompile("^" + Prefix + "(?:P|PL|F)_.*$")
+var environCleanupRegexp = regexp.MustCompile("^" + Prefix + "(?:P|PL|F|ARG)_.*$")
 
 func Environ() []string {
 	baseEnviron := os.Environ()
@@ -21,6 +22,20 @@ func Environ() []string {
 	return processed
 }
 
+// Lookup searches for a value of the variable in a list of environment
+// values. If variable is defined several times, the last one wins.
+func Lookup(environ []string, name string) (string, bool) {
+	prefix := name + "="
+
+	for i := len(environ) - 1; i >= 0; i-- {
+		if strings.HasPrefix(environ[i], prefix) {
+			return environ[i][len(prefix):], true
+		}
+	}
+
+	return "", false
+}
+
 func MakeValue(name, value string) string {
 	return name + "=" + value
 }
@@ -31,10 +31,27 @@ func TestEnviron(t *testing.T) {
 	t.Setenv(env.ParameterName("X"), "1")
 	t.Setenv(env.FlagName("Y"), "1")
 	t.Setenv(env.ParameterNameList("Z"), "1")
+	t.Setenv(env.ArgName("W"), "1")
 
 	for _, value := range env.Environ() {
 		assert.False(t, strings.HasPrefix(value, env.FlagPrefix))
 		assert.False(t, strings.HasPrefix(value, env.ParameterPrefix))
 		assert.False(t, strings.HasPrefix(value, env.ParameterPrefixList))
+		assert.False(t, strings.HasPrefix(value, env.ArgPrefix))
 	}
 }
+
+func TestLookup(t *testing.T) {
+	environ := []string{"A=1", "AB=2", "B=", "A=3"}
+
+	value, ok := env.Lookup(environ, "A")
+	assert.True(t, ok)
+	assert.Equal(t, "3", value)
+
+	value, ok = env.Lookup(environ, "B")
+	assert.True(t, ok)
+	assert.Empty(t, value)
+
+	_, ok = env.Lookup(environ, "C")
+	assert.False(t, ok)
+}
@@ -26,6 +26,9 @@ func Collect(validScripts []*script.Script) ([]string, error) { //nolint: cyclop
 
 		safeFiles[scr.Path()] = true
 		safeFiles[paths.ConfigNamespaceScriptVault(scr.Namespace)] = true
+		safeFiles[paths.ConfigNamespaceScriptVaultBackup(scr.Namespace)] = true
+		safeFiles[paths.ConfigNamespaceDotenv(scr.Namespace)] = true
+		safeFiles[paths.ConfigNamespaceScriptDotenv(scr.Namespace, scr.Executable)] = true
 
 		safePaths.Set(patricia.Prefix(scr.Path()), true)
 		safePaths.Set(patricia.Prefix(scr.DataPath()), true)
@@ -33,6 +33,10 @@ func (suite *GCTestSuite) SetupTest() {
 	suite.EnsureScriptConfig("x", "valid_script_with_config", "description = '1'")
 
 	suite.EnsureScript("x", "valid_script_without_config", "echo 2")
+	suite.EnsureFile(paths.ConfigNamespaceDotenv("x"), "A=1", 0o600)
+	suite.EnsureFile(paths.ConfigNamespaceScriptDotenv("x", "valid_script_without_config"), "A=1", 0o600)
+	suite.EnsureFile(paths.ConfigNamespaceScriptDotenv("x", "absent_script"), "A=1", 0o600)
+	suite.EnsureFile(paths.ConfigNamespaceScriptVaultBackup("x"), "backup", 0o600)
 
 	suite.EnsureScript("x", "valid_script_with_incorrect_config", "echo 2")
 	suite.EnsureScriptConfig("x", "valid_script_with_incorrect_config", "{")
@@ -76,6 +80,7 @@ func (suite *GCTestSuite) TestCollect() {
 		paths.CacheNamespace("y"),
 		paths.CacheNamespace("y2"),
 		paths.ConfigNamespaceScriptConfig("x", "valid_script_with_incorrect_config"),
+		paths.ConfigNamespaceScriptDotenv("x", "absent_script"),
 		paths.ConfigNamespace("y"),
 		paths.DataNamespace("y1"),
 		paths.DataNamespace("y2"),
@@ -97,6 +102,10 @@ func (suite *GCTestSuite) TestRemove() {
 	suite.NoDirExists(paths.CacheNamespace("y2"))
 	suite.NoDirExists(paths.ConfigNamespace("y"))
 	suite.NoFileExists(paths.ConfigNamespaceScriptConfig("x", "valid_script_without_config"))
+	suite.FileExists(paths.ConfigNamespaceDotenv("x"))
+	suite.FileExists(paths.ConfigNamespaceScriptDotenv("x", "valid_script_without_config"))
+	suite.NoFileExists(paths.ConfigNamespaceScriptDotenv("x", "absent_script"))
+	suite.FileExists(paths.ConfigNamespaceScriptVaultBackup("x"))
 }
 
 func TestGC(t *testing.T) {
@@ -0,0 +1,132 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+const FilePermission fs.FileMode = 0o600
+
+type Record struct {
+	Namespace   string              `json:"namespace"`
+	Script      string              `json:"script"`
+	ID          string              `json:"id"`
+	ChainID     string              `json:"chain_id"`
+	Attempt     int                 `json:"attempt,omitempty"`
+	Parameters  map[string][]string `json:"parameters"`
+	Flags       map[string]bool     `json:"flags"`
+	Positional  []string            `json:"positional"`
+	WorkingDir  string              `json:"working_dir"`
+	StartedAt   time.Time           `json:"started_at"`
+	ExitCode    int                 `json:"exit_code"`
+	TimedOut    bool                `json:"timed_out"`
+	UserTime    time.Duration       `json:"user_time"`
+	SystemTime  time.Duration       `json:"system_time"`
+	ElapsedTime time.Duration       `json:"elapsed_time"`
+}
+
+type Filter struct {
+	ExitCodes []int
+	Since     time.Time
+	Until     time.Time
+}
+
+func (f Filter) Match(record Record) bool {
+	if !f.Since.IsZero() && record.StartedAt.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && record.StartedAt.After(f.Until) {
+		return false
+	}
+
+	if len(f.ExitCodes) == 0 {
+		return true
+	}
+
+	for _, code := range f.ExitCodes {
+		if code == record.ExitCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Append adds a new record to the end of history file. Each record
+// is a single JSON line so writes of different chore processes do not
+// interleave.
+func Append(path string, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("cannot marshal record: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, FilePermission)
+	if err != nil {
+		return fmt.Errorf("cannot open history file: %w", err)
+	}
+
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write to history file: %w", err)
+	}
+
+	return nil
+}
+
+// Read returns all records of the history file which match a given
+// filter. Records are sorted by their start time.
+func Read(path string, filter Filter) ([]Record, error) {
+	file, err := os.Open(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot open history file: %w", err)
+	}
+
+	defer file.Close()
+
+	records := []Record{}
+	scanner := bufio.NewScanner(file)
+
+	scanner.Buffer(nil, bufio.MaxScanTokenSize*16) //nolint: gomnd
+
+	for scanner.Scan() {
+		record := Record{}
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			log.Printf("cannot parse history record of %s: %v", path, err)
+
+			continue
+		}
+
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read history file: %w", err)
+	}
+
+	Sort(records)
+
+	return records, nil
+}
+
+func Sort(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].StartedAt.Before(records[j].StartedAt)
+	})
+}
@@ -0,0 +1,108 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/history"
+	"github.com/stretchr/testify/suite"
+)
+
+type HistoryTestSuite struct {
+	suite.Suite
+
+	path string
+	now  time.Time
+}
+
+func (suite *HistoryTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "history")
+	suite.now = time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *HistoryTestSuite) Fill() {
+	records := []history.Record{
+		{ID: "2", ExitCode: 1, StartedAt: suite.now.Add(time.Hour)},
+		{ID: "1", ExitCode: 0, StartedAt: suite.now},
+		{ID: "3", ExitCode: 0, StartedAt: suite.now.Add(2 * time.Hour)},
+	}
+
+	for _, record := range records {
+		suite.NoError(history.Append(suite.path, record))
+	}
+}
+
+func (suite *HistoryTestSuite) IDs(records []history.Record) []string {
+	ids := make([]string, 0, len(records))
+
+	for _, v := range records {
+		ids = append(ids, v.ID)
+	}
+
+	return ids
+}
+
+func (suite *HistoryTestSuite) TestAbsentFile() {
+	records, err := history.Read(suite.path, history.Filter{})
+	suite.NoError(err)
+	suite.Empty(records)
+}
+
+func (suite *HistoryTestSuite) TestPermissions() {
+	suite.NoError(history.Append(suite.path, history.Record{}))
+
+	stat, err := os.Stat(suite.path)
+	suite.NoError(err)
+	suite.Equal(history.FilePermission, stat.Mode().Perm())
+}
+
+func (suite *HistoryTestSuite) TestReadAll() {
+	suite.Fill()
+
+	records, err := history.Read(suite.path, history.Filter{})
+	suite.NoError(err)
+	suite.Equal([]string{"1", "2", "3"}, suite.IDs(records))
+	suite.Equal(1, records[1].ExitCode)
+}
+
+func (suite *HistoryTestSuite) TestSkipBrokenLines() {
+	suite.Fill()
+
+	file, err := os.OpenFile(suite.path, os.O_WRONLY|os.O_APPEND, 0)
+	suite.NoError(err)
+
+	_, err = file.WriteString("{xxx\n")
+	suite.NoError(err)
+	suite.NoError(file.Close())
+
+	records, err := history.Read(suite.path, history.Filter{})
+	suite.NoError(err)
+	suite.Len(records, 3)
+}
+
+func (suite *HistoryTestSuite) TestFilterExitCode() {
+	suite.Fill()
+
+	records, err := history.Read(suite.path, history.Filter{
+		ExitCodes: []int{0},
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"1", "3"}, suite.IDs(records))
+}
+
+func (suite *HistoryTestSuite) TestFilterTime() {
+	suite.Fill()
+
+	records, err := history.Read(suite.path, history.Filter{
+		Since: suite.now.Add(time.Minute),
+		Until: suite.now.Add(90 * time.Minute),
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"2"}, suite.IDs(records))
+}
+
+func TestHistory(t *testing.T) {
+	suite.Run(t, &HistoryTestSuite{})
+}
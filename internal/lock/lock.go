@@ -0,0 +1,102 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	RetryEvery = 100 * time.Millisecond
+
+	FilePermission fs.FileMode = 0o600
+	DirPermission  fs.FileMode = 0o700
+)
+
+var (
+	ErrLocked      = errors.New("lock is held by another process")
+	ErrUnsupported = errors.New("locks are not supported on this platform")
+)
+
+type Lock struct {
+	file *os.File
+}
+
+func (l *Lock) Release() error {
+	if err := unlockFile(l.file); err != nil {
+		l.file.Close()
+
+		return fmt.Errorf("cannot release lock: %w", err)
+	}
+
+	return l.file.Close()
+}
+
+// Acquire takes an exclusive advisory lock on a file at a given path.
+// If wait is false and lock is held by someone else, ErrLocked is
+// returned immediately. Otherwise, it waits until lock is released or
+// context is closed.
+func Acquire(ctx context.Context, path string, wait bool) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), DirPermission); err != nil {
+		return nil, fmt.Errorf("cannot create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, FilePermission)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open lock file: %w", err)
+	}
+
+	ticker := time.NewTicker(RetryEvery)
+	defer ticker.Stop()
+
+	for {
+		err := tryLockFile(file)
+
+		switch {
+		case err == nil:
+			return &Lock{file: file}, nil
+		case !errors.Is(err, ErrLocked):
+			file.Close()
+
+			return nil, fmt.Errorf("cannot acquire lock: %w", err)
+		case !wait:
+			file.Close()
+
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			file.Close()
+
+			return nil, fmt.Errorf("cannot wait for a lock: %w", context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsLocked reports if a file at a given path is locked by someone
+// else. Unlike Acquire, it never creates a file.
+func IsLocked(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("cannot open lock file: %w", err)
+	}
+
+	defer file.Close()
+
+	err = tryLockFile(file)
+
+	switch {
+	case errors.Is(err, ErrLocked):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("cannot check lock: %w", err)
+	}
+
+	return false, nil
+}
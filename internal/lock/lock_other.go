@@ -0,0 +1,13 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos)
+
+package lock
+
+import "os"
+
+func tryLockFile(_ *os.File) error {
+	return ErrUnsupported
+}
+
+func unlockFile(_ *os.File) error {
+	return ErrUnsupported
+}
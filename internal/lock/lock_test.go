@@ -0,0 +1,100 @@
+package lock_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/lock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockTestSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (suite *LockTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "locks", "lock")
+}
+
+func (suite *LockTestSuite) TestAcquireRelease() {
+	lck, err := lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+	suite.FileExists(suite.path)
+	suite.NoError(lck.Release())
+
+	lck, err = lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+	suite.NoError(lck.Release())
+}
+
+func (suite *LockTestSuite) TestNoWait() {
+	lck, err := lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+
+	defer lck.Release()
+
+	_, err = lock.Acquire(context.Background(), suite.path, false)
+	suite.ErrorIs(err, lock.ErrLocked)
+}
+
+func (suite *LockTestSuite) TestWaitTimeout() {
+	lck, err := lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+
+	defer lck.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lock.RetryEvery)
+	defer cancel()
+
+	_, err = lock.Acquire(ctx, suite.path, true)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (suite *LockTestSuite) TestWait() {
+	lck, err := lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+
+	go func() {
+		time.Sleep(2 * lock.RetryEvery)
+		lck.Release()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	lck2, err := lock.Acquire(ctx, suite.path, true)
+	suite.NoError(err)
+	suite.NoError(lck2.Release())
+}
+
+func (suite *LockTestSuite) TestIsLocked() {
+	_, err := lock.IsLocked(suite.path)
+	suite.ErrorIs(err, os.ErrNotExist)
+
+	lck, err := lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+
+	locked, err := lock.IsLocked(suite.path)
+	suite.NoError(err)
+	suite.True(locked)
+
+	suite.NoError(lck.Release())
+
+	locked, err = lock.IsLocked(suite.path)
+	suite.NoError(err)
+	suite.False(locked)
+
+	// checking must not take a lock
+	lck, err = lock.Acquire(context.Background(), suite.path, false)
+	suite.NoError(err)
+	suite.NoError(lck.Release())
+}
+
+func TestLock(t *testing.T) {
+	suite.Run(t, &LockTestSuite{})
+}
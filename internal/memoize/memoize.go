@@ -0,0 +1,97 @@
+package memoize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	StdoutFileName = "stdout"
+	StderrFileName = "stderr"
+	MetaFileName   = "meta.json"
+
+	FilePermission fs.FileMode = 0o600
+	DirPermission  fs.FileMode = 0o700
+)
+
+var (
+	ErrNotFound = errors.New("memoized result is not found")
+	ErrExpired  = errors.New("memoized result is expired")
+)
+
+type meta struct {
+	RunID     string    `json:"run_id"`
+	ExitCode  int       `json:"exit_code"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Result is a stored result of some previous run.
+type Result struct {
+	RunID     string
+	ExitCode  int
+	CreatedAt time.Time
+
+	dir string
+}
+
+// Replay writes stored stdout and stderr into given writers. Please
+// pay attention that original interleaving of streams is not preserved:
+// stdout goes first.
+func (r Result) Replay(stdout, stderr io.Writer) error {
+	if err := replayFile(filepath.Join(r.dir, StdoutFileName), stdout); err != nil {
+		return fmt.Errorf("cannot replay stdout: %w", err)
+	}
+
+	if err := replayFile(filepath.Join(r.dir, StderrFileName), stderr); err != nil {
+		return fmt.Errorf("cannot replay stderr: %w", err)
+	}
+
+	return nil
+}
+
+// Load returns a result stored in dir if it is younger than ttl.
+func Load(dir string, ttl time.Duration) (Result, error) {
+	data, err := os.ReadFile(filepath.Join(dir, MetaFileName))
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return Result{}, ErrNotFound
+	case err != nil:
+		return Result{}, fmt.Errorf("cannot read metadata: %w", err)
+	}
+
+	stored := meta{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return Result{}, fmt.Errorf("cannot parse metadata: %w", err)
+	}
+
+	if time.Since(stored.CreatedAt) > ttl {
+		return Result{}, ErrExpired
+	}
+
+	return Result{
+		RunID:     stored.RunID,
+		ExitCode:  stored.ExitCode,
+		CreatedAt: stored.CreatedAt,
+		dir:       dir,
+	}, nil
+}
+
+func replayFile(path string, writer io.Writer) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(writer, file)
+
+	return err
+}
@@ -0,0 +1,100 @@
+package memoize_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/memoize"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoizeTestSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (suite *MemoizeTestSuite) SetupTest() {
+	suite.dir = filepath.Join(suite.T().TempDir(), "checksum")
+}
+
+func (suite *MemoizeTestSuite) Record(runID, stdout, stderr string) {
+	recorder, err := memoize.NewRecorder(suite.dir, runID)
+	suite.NoError(err)
+
+	fmt.Fprint(recorder.Stdout, stdout)
+	fmt.Fprint(recorder.Stderr, stderr)
+
+	suite.NoError(recorder.Commit(0))
+}
+
+func (suite *MemoizeTestSuite) TestNotFound() {
+	_, err := memoize.Load(suite.dir, time.Hour)
+	suite.ErrorIs(err, memoize.ErrNotFound)
+}
+
+func (suite *MemoizeTestSuite) TestExpired() {
+	suite.Record("run1", "out", "err")
+
+	_, err := memoize.Load(suite.dir, time.Nanosecond)
+	suite.ErrorIs(err, memoize.ErrExpired)
+}
+
+func (suite *MemoizeTestSuite) TestReplay() {
+	suite.Record("run1", "out", "err")
+
+	result, err := memoize.Load(suite.dir, time.Hour)
+	suite.NoError(err)
+	suite.Equal("run1", result.RunID)
+	suite.Zero(result.ExitCode)
+	suite.WithinDuration(time.Now(), result.CreatedAt, time.Minute)
+
+	stdout := &strings.Builder{}
+	stderr := &strings.Builder{}
+
+	suite.NoError(result.Replay(stdout, stderr))
+	suite.Equal("out", stdout.String())
+	suite.Equal("err", stderr.String())
+}
+
+func (suite *MemoizeTestSuite) TestOverwrite() {
+	suite.Record("run1", "out1", "err1")
+	suite.Record("run2", "out2", "err2")
+
+	result, err := memoize.Load(suite.dir, time.Hour)
+	suite.NoError(err)
+	suite.Equal("run2", result.RunID)
+
+	stdout := &strings.Builder{}
+	stderr := &strings.Builder{}
+
+	suite.NoError(result.Replay(stdout, stderr))
+	suite.Equal("out2", stdout.String())
+	suite.Equal("err2", stderr.String())
+}
+
+func (suite *MemoizeTestSuite) TestDiscard() {
+	suite.Record("run1", "out", "err")
+
+	recorder, err := memoize.NewRecorder(suite.dir, "run2")
+	suite.NoError(err)
+
+	fmt.Fprint(recorder.Stdout, "out2")
+	suite.NoError(recorder.Discard())
+
+	result, err := memoize.Load(suite.dir, time.Hour)
+	suite.NoError(err)
+	suite.Equal("run1", result.RunID)
+
+	entries, err := os.ReadDir(filepath.Dir(suite.dir))
+	suite.NoError(err)
+	suite.Len(entries, 1)
+}
+
+func TestMemoize(t *testing.T) {
+	suite.Run(t, &MemoizeTestSuite{})
+}
@@ -0,0 +1,142 @@
+package memoize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Recorder stores output of a run into temporary directory. Only
+// committed results become visible for Load.
+type Recorder struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	dir     string
+	tempDir string
+	runID   string
+	files   []*os.File
+	writers []*recordWriter
+}
+
+// Commit makes a recorded result available at dir replacing any
+// previous one.
+func (r *Recorder) Commit(exitCode int) error {
+	if err := r.close(); err != nil {
+		r.Discard()
+
+		return err
+	}
+
+	data, err := json.Marshal(meta{
+		RunID:     r.runID,
+		ExitCode:  exitCode,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		r.Discard()
+
+		return fmt.Errorf("cannot marshal metadata: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(r.tempDir, MetaFileName), data, FilePermission); err != nil {
+		r.Discard()
+
+		return fmt.Errorf("cannot write metadata: %w", err)
+	}
+
+	if err := os.RemoveAll(r.dir); err != nil {
+		r.Discard()
+
+		return fmt.Errorf("cannot remove previous result: %w", err)
+	}
+
+	if err := os.Rename(r.tempDir, r.dir); err != nil {
+		r.Discard()
+
+		return fmt.Errorf("cannot move result: %w", err)
+	}
+
+	return nil
+}
+
+// Discard drops everything that was recorded.
+func (r *Recorder) Discard() error {
+	r.close() //nolint: errcheck
+
+	return os.RemoveAll(r.tempDir)
+}
+
+func (r *Recorder) close() error {
+	errs := []error{}
+
+	for _, file := range r.files {
+		if err := file.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, writer := range r.writers {
+		errs = append(errs, writer.err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewRecorder prepares a recorder for a result which is going to be
+// stored in dir.
+func NewRecorder(dir, runID string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(dir), DirPermission); err != nil {
+		return nil, fmt.Errorf("cannot create memoize directory: %w", err)
+	}
+
+	tempDir, err := os.MkdirTemp(filepath.Dir(dir), ".tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+
+	recorder := &Recorder{
+		dir:     dir,
+		tempDir: tempDir,
+		runID:   runID,
+	}
+
+	for _, name := range []string{StdoutFileName, StderrFileName} {
+		file, err := os.OpenFile(
+			filepath.Join(tempDir, name),
+			os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+			FilePermission)
+		if err != nil {
+			recorder.Discard()
+
+			return nil, fmt.Errorf("cannot open %s file: %w", name, err)
+		}
+
+		recorder.files = append(recorder.files, file)
+		recorder.writers = append(recorder.writers, &recordWriter{writer: file})
+	}
+
+	recorder.Stdout = recorder.writers[0]
+	recorder.Stderr = recorder.writers[1]
+
+	return recorder, nil
+}
+
+// recordWriter never fails so a broken cache does not break an
+// output of the script. An error is reported on commit instead.
+type recordWriter struct {
+	writer io.Writer
+	err    error
+}
+
+func (r *recordWriter) Write(p []byte) (int, error) {
+	if r.err == nil {
+		_, r.err = r.writer.Write(p)
+	}
+
+	return len(p), nil
+}
@@ -9,7 +9,13 @@
 const (
 	ChoreDir          = "chore"
 	VaultFileName     = ".vault"
+	VaultBackupSuffix = ".bak"
+	DotenvFileName    = ".env"
 	AppConfigFileName = "config.toml"
+	HistoryFileName   = ".history.jsonl"
+	LogsDirName       = ".logs"
+	LocksDirName      = ".locks"
+	MemoizeDirName    = ".memoize"
 )
 
 func ConfigRoot() string {
@@ -32,6 +38,18 @@ func ConfigNamespaceScriptVault(ns string) string {
 	return filepath.Join(ConfigNamespace(ns), VaultFileName)
 }
 
+func ConfigNamespaceScriptVaultBackup(ns string) string {
+	return ConfigNamespaceScriptVault(ns) + VaultBackupSuffix
+}
+
+func ConfigNamespaceDotenv(ns string) string {
+	return filepath.Join(ConfigNamespace(ns), DotenvFileName)
+}
+
+func ConfigNamespaceScriptDotenv(ns, script string) string {
+	return filepath.Join(ConfigNamespace(ns), "."+script+DotenvFileName)
+}
+
 func ConfigNamespaceScript(ns, script string) string {
 	return filepath.Join(ConfigNamespace(ns), script)
 }
@@ -64,6 +82,10 @@ func CacheNamespaceScript(ns, script string) string {
 	return filepath.Join(CacheNamespace(ns), script)
 }
 
+func CacheNamespaceScriptMemoize(ns, script string) string {
+	return filepath.Join(CacheNamespaceScript(ns, script), MemoizeDirName)
+}
+
 func StateRoot() string {
 	return filepath.Join(xdg.StateHome, ChoreDir)
 }
@@ -75,3 +97,15 @@ func StateNamespace(ns string) string {
 func StateNamespaceScript(ns, script string) string {
 	return filepath.Join(StateNamespace(ns), script)
 }
+
+func StateNamespaceScriptHistory(ns, script string) string {
+	return filepath.Join(StateNamespaceScript(ns, script), HistoryFileName)
+}
+
+func StateNamespaceScriptLogs(ns, script string) string {
+	return filepath.Join(StateNamespaceScript(ns, script), LogsDirName)
+}
+
+func StateNamespaceScriptLocks(ns, script string) string {
+	return filepath.Join(StateNamespaceScript(ns, script), LocksDirName)
+}
@@ -0,0 +1,166 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	ErrValueRequired = errors.New("value is required")
+	ErrInvalidChoice = errors.New("invalid choice")
+	ErrInvalidAnswer = errors.New("answer yes or no")
+)
+
+// Validator checks an answer. If it returns an error, the question is
+// asked again.
+type Validator func(string) error
+
+type Prompt struct {
+	input  io.Reader
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+// Ask asks for a single line of input. Secret answers are not echoed
+// if input is a terminal.
+func (p *Prompt) Ask(question string, secret bool, validator Validator) (string, error) {
+	for {
+		fmt.Fprintf(p.writer, "%s: ", question)
+
+		answer, err := p.readAnswer(secret)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case answer == "":
+			err = ErrValueRequired
+		case validator != nil:
+			err = validator(answer)
+		}
+
+		if err == nil {
+			return answer, nil
+		}
+
+		fmt.Fprintf(p.writer, "Incorrect value: %v. Please try again.\n", err)
+	}
+}
+
+// Choose asks to select one of given choices. Both a number of the
+// choice and a value itself are accepted. A value is returned.
+func (p *Prompt) Choose(question string, choices []string) (string, error) {
+	for idx, choice := range choices {
+		fmt.Fprintf(p.writer, "  %d) %s\n", idx+1, choice)
+	}
+
+	answer, err := p.Ask(question, false, func(answer string) error {
+		if _, ok := pickChoice(answer, choices); !ok {
+			return ErrInvalidChoice
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	value, _ := pickChoice(answer, choices)
+
+	return value, nil
+}
+
+// Confirm asks a yes/no question.
+func (p *Prompt) Confirm(question string) (bool, error) {
+	answer, err := p.Ask(question+" [y/n]", false, func(answer string) error {
+		if _, ok := parseYesNo(answer); !ok {
+			return ErrInvalidAnswer
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	value, _ := parseYesNo(answer)
+
+	return value, nil
+}
+
+func (p *Prompt) readAnswer(secret bool) (string, error) {
+	if file, ok := p.input.(*os.File); ok && secret && term.IsTerminal(int(file.Fd())) {
+		value, err := term.ReadPassword(int(file.Fd()))
+
+		fmt.Fprintln(p.writer)
+
+		if err != nil {
+			return "", fmt.Errorf("cannot read value: %w", err)
+		}
+
+		return string(value), nil
+	}
+
+	line, err := p.reader.ReadString('\n')
+
+	switch {
+	case errors.Is(err, io.EOF) && line != "":
+	case err != nil:
+		return "", fmt.Errorf("cannot read value: %w", err)
+	}
+
+	// spaces could be a part of a secret, only a line terminator
+	// has to be removed.
+	if secret {
+		return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+func pickChoice(answer string, choices []string) (string, bool) {
+	for _, choice := range choices {
+		if choice == answer {
+			return choice, true
+		}
+	}
+
+	if idx, err := strconv.Atoi(answer); err == nil && idx > 0 && idx <= len(choices) {
+		return choices[idx-1], true
+	}
+
+	return "", false
+}
+
+func parseYesNo(answer string) (bool, bool) {
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	}
+
+	return false, false
+}
+
+// IsInteractive tells if user can answer questions.
+func IsInteractive(input io.Reader) bool {
+	file, ok := input.(*os.File)
+
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+func New(input io.Reader, writer io.Writer) *Prompt {
+	return &Prompt{
+		input:  input,
+		reader: bufio.NewReader(input),
+		writer: writer,
+	}
+}
@@ -0,0 +1,112 @@
+package prompt_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/9seconds/chore/internal/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PromptTestSuite struct {
+	suite.Suite
+
+	output *strings.Builder
+}
+
+func (suite *PromptTestSuite) SetupTest() {
+	suite.output = &strings.Builder{}
+}
+
+func (suite *PromptTestSuite) Make(input string) *prompt.Prompt {
+	return prompt.New(strings.NewReader(input), suite.output)
+}
+
+func (suite *PromptTestSuite) TestAsk() {
+	value, err := suite.Make("  hello \n").Ask("name", false, nil)
+	suite.NoError(err)
+	suite.Equal("hello", value)
+	suite.Equal("name: ", suite.output.String())
+}
+
+func (suite *PromptTestSuite) TestAskWithoutNewline() {
+	value, err := suite.Make("hello").Ask("name", true, nil)
+	suite.NoError(err)
+	suite.Equal("hello", value)
+}
+
+func (suite *PromptTestSuite) TestAskSecretKeepsSpaces() {
+	value, err := suite.Make("  hello \r\n").Ask("name", true, nil)
+	suite.NoError(err)
+	suite.Equal("  hello ", value)
+}
+
+func (suite *PromptTestSuite) TestAskReprompt() {
+	value, err := suite.Make("\nbad\ngood\n").Ask("name", false, func(value string) error {
+		if value != "good" {
+			return errors.New("not good")
+		}
+
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal("good", value)
+	suite.Contains(suite.output.String(), "value is required")
+	suite.Contains(suite.output.String(), "not good")
+}
+
+func (suite *PromptTestSuite) TestAskEOF() {
+	_, err := suite.Make("\n").Ask("name", false, nil)
+	suite.Error(err)
+}
+
+func (suite *PromptTestSuite) TestChoose() {
+	testTable := map[string]string{
+		"1\n":    "a",
+		"3\n":    "c",
+		"b\n":    "b",
+		"0\nc\n": "c",
+		"x\n2\n": "b",
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		suite.T().Run(strings.TrimSpace(testValue), func(t *testing.T) {
+			value, err := suite.Make(testValue).Choose("pick", []string{"a", "b", "c"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+		})
+	}
+
+	suite.Contains(suite.output.String(), "  2) b\n")
+}
+
+func (suite *PromptTestSuite) TestConfirm() {
+	testTable := map[string]bool{
+		"y\n":         true,
+		"YES\n":       true,
+		"n\n":         false,
+		"maybe\nno\n": false,
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		suite.T().Run(strings.TrimSpace(testValue), func(t *testing.T) {
+			value, err := suite.Make(testValue).Confirm("sure?")
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value)
+		})
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	suite.Run(t, &PromptTestSuite{})
+}
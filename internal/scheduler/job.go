@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/9seconds/chore/internal/script"
+	"github.com/9seconds/chore/internal/script/config"
+)
+
+type Job struct {
+	Namespace  string
+	Executable string
+	Schedule   config.Schedule
+}
+
+func (j Job) String() string {
+	return j.Namespace + "/" + j.Executable
+}
+
+// Discover finds all scripts which have a schedule. Scripts with
+// broken configs are skipped.
+func Discover() ([]Job, error) {
+	namespaces, err := script.ListNamespaces()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list namespaces: %w", err)
+	}
+
+	jobs := []Job{}
+
+	for _, namespace := range namespaces {
+		scripts, err := script.ListScripts(namespace)
+		if err != nil {
+			log.Printf("cannot list scripts of %s: %v", namespace, err)
+
+			continue
+		}
+
+		for _, executable := range scripts {
+			scr, err := script.New(namespace, executable)
+			if err != nil {
+				log.Printf("cannot initialize script %s/%s: %v", namespace, executable, err)
+
+				continue
+			}
+
+			if scr.Config.Schedule.Enabled() {
+				jobs = append(jobs, Job{
+					Namespace:  namespace,
+					Executable: executable,
+					Schedule:   scr.Config.Schedule,
+				})
+			}
+		}
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,42 @@
+package scheduler_test
+
+import (
+	"testing"
+
+	"github.com/9seconds/chore/internal/scheduler"
+	"github.com/9seconds/chore/internal/testlib"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobTestSuite struct {
+	suite.Suite
+
+	testlib.CustomRootTestSuite
+}
+
+func (suite *JobTestSuite) SetupTest() {
+	suite.CustomRootTestSuite.Setup(suite.T())
+
+	suite.EnsureScript("ns", "a", "echo 1")
+	suite.EnsureScriptConfig("ns", "a", `schedule = "@hourly"`)
+	suite.EnsureScript("ns", "b", "echo 1")
+	suite.EnsureScript("ns", "c", "echo 1")
+	suite.EnsureScriptConfig("ns", "c", `schedule = "xxx"`)
+	suite.EnsureScript("xx", "d", "echo 1")
+	suite.EnsureScriptConfig("xx", "d", `every = "1m"`)
+}
+
+func (suite *JobTestSuite) TestDiscover() {
+	jobs, err := scheduler.Discover()
+	suite.NoError(err)
+	suite.Len(jobs, 2)
+
+	suite.Equal("ns/a", jobs[0].String())
+	suite.Equal("@hourly", jobs[0].Schedule.Spec)
+	suite.Equal("xx/d", jobs[1].String())
+	suite.Equal("@every 1m0s", jobs[1].Schedule.Spec)
+}
+
+func TestJob(t *testing.T) {
+	suite.Run(t, &JobTestSuite{})
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	TickEvery   = time.Second
+	ReloadEvery = 10 * time.Second
+)
+
+// Runner executes a job. It should block until execution is finished.
+type Runner func(context.Context, Job) error
+
+type scheduledJob struct {
+	job  Job
+	next time.Time
+}
+
+type Scheduler struct {
+	// Discover returns a list of jobs to schedule. It is called
+	// periodically so changes in configs are picked up.
+	Discover func() ([]Job, error)
+	Runner   Runner
+
+	TickEvery   time.Duration
+	ReloadEvery time.Duration
+
+	jobs    map[string]*scheduledJob
+	running map[string]bool
+	mutex   sync.Mutex
+	waiters sync.WaitGroup
+}
+
+// Run executes jobs according to their schedules until context is
+// closed. A job is never executed concurrently with itself: if the
+// previous run is not finished yet, the next one is skipped.
+func (s *Scheduler) Run(ctx context.Context) {
+	tickTicker := time.NewTicker(s.TickEvery)
+	defer tickTicker.Stop()
+
+	reloadTicker := time.NewTicker(s.ReloadEvery)
+	defer reloadTicker.Stop()
+
+	defer s.waiters.Wait()
+
+	s.reload(time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloadTicker.C:
+			s.reload(time.Now())
+		case now := <-tickTicker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+func (s *Scheduler) reload(now time.Time) {
+	discovered, err := s.Discover()
+	if err != nil {
+		log.Printf("cannot discover scheduled scripts: %v", err)
+
+		return
+	}
+
+	jobs := make(map[string]*scheduledJob, len(discovered))
+
+	for _, job := range discovered {
+		key := job.String()
+
+		if old, ok := s.jobs[key]; ok && old.job.Schedule.Spec == job.Schedule.Spec {
+			jobs[key] = old
+
+			continue
+		}
+
+		next := job.Schedule.Next(now)
+
+		log.Printf("schedule %s with %q, next run at %v", key, job.Schedule.Spec, next)
+
+		jobs[key] = &scheduledJob{
+			job:  job,
+			next: next,
+		}
+	}
+
+	for key := range s.jobs {
+		if _, ok := jobs[key]; !ok {
+			log.Printf("unschedule %s", key)
+		}
+	}
+
+	s.jobs = jobs
+}
+
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running == nil {
+		s.running = map[string]bool{}
+	}
+
+	for key, scheduled := range s.jobs {
+		if now.Before(scheduled.next) {
+			continue
+		}
+
+		scheduled.next = scheduled.job.Schedule.Next(now)
+
+		if s.running[key] {
+			log.Printf("skip %s: previous run is not finished yet", key)
+
+			continue
+		}
+
+		s.running[key] = true
+		s.waiters.Add(1)
+
+		go func(key string, job Job) {
+			defer func() {
+				s.mutex.Lock()
+				delete(s.running, key)
+				s.mutex.Unlock()
+
+				s.waiters.Done()
+			}()
+
+			log.Printf("run %s", key)
+
+			if err := s.Runner(ctx, job); err != nil {
+				log.Printf("run of %s has failed: %v", key, err)
+			}
+		}(key, scheduled.job)
+	}
+}
+
+func New(runner Runner) *Scheduler {
+	return &Scheduler{
+		Discover:    Discover,
+		Runner:      runner,
+		TickEvery:   TickEvery,
+		ReloadEvery: ReloadEvery,
+	}
+}
@@ -0,0 +1,93 @@
+package scheduler_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/scheduler"
+	"github.com/9seconds/chore/internal/script/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+
+	job   scheduler.Job
+	calls atomic.Int32
+}
+
+func (suite *SchedulerTestSuite) SetupTest() {
+	conf, err := config.Parse(strings.NewReader(`every = "1s"`))
+	suite.NoError(err)
+
+	suite.job = scheduler.Job{
+		Namespace:  "ns",
+		Executable: "s",
+		Schedule:   conf.Schedule,
+	}
+
+	suite.calls.Store(0)
+}
+
+func (suite *SchedulerTestSuite) RunScheduler(runner scheduler.Runner, discover func() ([]scheduler.Job, error)) {
+	sched := scheduler.New(runner)
+	sched.Discover = discover
+	sched.TickEvery = 10 * time.Millisecond
+	sched.ReloadEvery = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+
+	sched.Run(ctx)
+}
+
+func (suite *SchedulerTestSuite) TestRun() {
+	suite.RunScheduler(func(_ context.Context, job scheduler.Job) error {
+		suite.Equal("ns/s", job.String())
+		suite.calls.Add(1)
+
+		return nil
+	}, func() ([]scheduler.Job, error) {
+		return []scheduler.Job{suite.job}, nil
+	})
+
+	suite.GreaterOrEqual(suite.calls.Load(), int32(2))
+}
+
+func (suite *SchedulerTestSuite) TestNoOverlap() {
+	suite.RunScheduler(func(ctx context.Context, _ scheduler.Job) error {
+		suite.calls.Add(1)
+		<-ctx.Done()
+
+		return nil
+	}, func() ([]scheduler.Job, error) {
+		return []scheduler.Job{suite.job}, nil
+	})
+
+	suite.EqualValues(1, suite.calls.Load())
+}
+
+func (suite *SchedulerTestSuite) TestReload() {
+	discovered := atomic.Bool{}
+
+	suite.RunScheduler(func(_ context.Context, _ scheduler.Job) error {
+		suite.calls.Add(1)
+
+		return nil
+	}, func() ([]scheduler.Job, error) {
+		if discovered.Swap(true) {
+			return []scheduler.Job{suite.job}, nil
+		}
+
+		return nil, nil
+	})
+
+	suite.GreaterOrEqual(suite.calls.Load(), int32(1))
+}
+
+func TestScheduler(t *testing.T) {
+	suite.Run(t, &SchedulerTestSuite{})
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errIncorrectMaxRuns = errors.New("max_runs should be >= 0")
+
+type CaptureOutput struct {
+	Enabled    bool
+	Timestamps bool
+	MaxRuns    int
+	MaxSize    int64
+}
+
+func parseCaptureOutput(raw RawCaptureOutput) (CaptureOutput, error) {
+	maxSize, err := parseSize(raw.MaxSize)
+	if err != nil {
+		return CaptureOutput{}, fmt.Errorf("cannot parse max_size: %w", err)
+	}
+
+	if raw.MaxRuns < 0 {
+		return CaptureOutput{}, errIncorrectMaxRuns
+	}
+
+	return CaptureOutput{
+		Enabled:    raw.Enabled,
+		Timestamps: raw.Timestamps,
+		MaxRuns:    raw.MaxRuns,
+		MaxSize:    maxSize,
+	}, nil
+}
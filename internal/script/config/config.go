@@ -3,16 +3,33 @@
 import (
 	"fmt"
 	"io"
+	"os"
+	"time"
 
+	"github.com/9seconds/chore/internal/commands"
 	"github.com/9seconds/chore/internal/git"
 )
 
 type Config struct {
-	Description string
-	Git         git.AccessMode
-	Network     bool
-	Parameters  map[string]Parameter
-	Flags       map[string]Flag
+	Description     string
+	Git             git.AccessMode
+	Network         bool
+	Lock            LockMode
+	Timeout         time.Duration
+	StopSignal      os.Signal
+	StopGracePeriod time.Duration
+	Memoize         time.Duration
+	Schedule        Schedule
+	CaptureOutput   CaptureOutput
+	Retry           Retry
+	Limits          commands.Limits
+	Environment     Environment
+	Secrets         map[string]string
+	SecretFiles     map[string]string
+	ExpiredSecrets  ExpiredSecretsMode
+	Parameters      map[string]Parameter
+	Positional      []Positional
+	Flags           map[string]Flag
 }
 
 func Parse(reader io.Reader) (Config, error) { //nolint: cyclop
@@ -26,73 +43,185 @@ func Parse(reader io.Reader) (Config, error) { //nolint: cyclop
 		return Config{}, fmt.Errorf("cannot parse git access mode: %w", err)
 	}
 
+	lockMode, err := GetLockMode(raw.Lock)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse lock mode: %w", err)
+	}
+
+	timeout, err := parseDuration(raw.Timeout)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse timeout: %w", err)
+	}
+
+	stopGracePeriod, err := parseDuration(raw.StopGracePeriod)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse stop grace period: %w", err)
+	}
+
+	memoize, err := parseDuration(raw.Memoize)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse memoize: %w", err)
+	}
+
+	captureOutput, err := parseCaptureOutput(raw.CaptureOutput)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse capture_output: %w", err)
+	}
+
+	schedule, err := parseSchedule(raw.Schedule, raw.Every)
+	if err != nil {
+		return Config{}, err
+	}
+
+	retry, err := parseRetry(raw.Retry)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse retry: %w", err)
+	}
+
+	limits, err := parseLimits(raw.Limits)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse limits: %w", err)
+	}
+
+	environment, err := parseEnvironment(raw.Environment)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse environment: %w", err)
+	}
+
+	secrets, err := parseSecrets(raw.Secrets)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse secrets: %w", err)
+	}
+
+	secretFiles, err := parseSecretFiles(raw.SecretFiles, secrets)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse secret_files: %w", err)
+	}
+
+	expiredSecrets, err := GetExpiredSecretsMode(raw.ExpiredSecrets)
+	if err != nil {
+		return Config{}, fmt.Errorf("cannot parse expired_secrets: %w", err)
+	}
+
 	conf := Config{
-		Description: raw.Description,
-		Network:     raw.Network,
-		Git:         gitMode,
-		Parameters:  make(map[string]Parameter),
-		Flags:       make(map[string]Flag),
+		Description:     raw.Description,
+		Network:         raw.Network,
+		Git:             gitMode,
+		Lock:            lockMode,
+		Timeout:         timeout,
+		StopGracePeriod: stopGracePeriod,
+		Memoize:         memoize,
+		Schedule:        schedule,
+		CaptureOutput:   captureOutput,
+		Retry:           retry,
+		Limits:          limits,
+		Environment:     environment,
+		Secrets:         secrets,
+		SecretFiles:     secretFiles,
+		ExpiredSecrets:  expiredSecrets,
+		Parameters:      make(map[string]Parameter),
+		Flags:           make(map[string]Flag),
+	}
+
+	if raw.StopSignal != "" {
+		stopSignal, err := commands.ParseSignal(raw.StopSignal)
+		if err != nil {
+			return Config{}, fmt.Errorf("cannot parse stop signal: %w", err)
+		}
+
+		conf.StopSignal = stopSignal
 	}
 
 	for name, param := range raw.Flags {
-		conf.Flags[name] = NewFlag(param.Description, param.Required)
+		conf.Flags[name] = parseFlagDefault(
+			NewFlag(param.Description, param.Required),
+			param.Default)
 	}
 
 	for name, param := range raw.Parameters {
 		name := NormalizeName(name)
 
-		var (
-			value Parameter
-			err   error
-		)
-
-		switch param.Type {
-		case ParameterInteger:
-			value, err = NewInteger(param.Description, param.Required, param.Spec)
-		case ParameterString:
-			value, err = NewString(param.Description, param.Required, param.Spec)
-		case ParameterFloat:
-			value, err = NewFloat(param.Description, param.Required, param.Spec)
-		case ParameterURL:
-			value, err = NewURL(param.Description, param.Required, param.Spec)
-		case ParameterEmail:
-			value, err = NewEmail(param.Description, param.Required, param.Spec)
-		case ParameterEnum:
-			value, err = NewEnum(param.Description, param.Required, param.Spec)
-		case ParameterBase64:
-			value, err = NewBase64(param.Description, param.Required, param.Spec)
-		case ParameterHex:
-			value, err = NewHex(param.Description, param.Required, param.Spec)
-		case ParameterHostname:
-			value, err = NewHostname(param.Description, param.Required, param.Spec)
-		case ParameterMac:
-			value, err = NewMac(param.Description, param.Required, param.Spec)
-		case ParameterJSON:
-			value, err = NewJSON(param.Description, param.Required, param.Spec)
-		case ParameterXML:
-			value, err = NewXML(param.Description, param.Required, param.Spec)
-		case ParameterUUID:
-			value, err = NewUUID(param.Description, param.Required, param.Spec)
-		case ParameterDirectory:
-			value, err = NewDirectory(param.Description, param.Required, param.Spec)
-		case ParameterFile:
-			value, err = NewFile(param.Description, param.Required, param.Spec)
-		case ParameterSemver:
-			value, err = NewSemver(param.Description, param.Required, param.Spec)
-		case ParameterDatetime:
-			value, err = NewDatetime(param.Description, param.Required, param.Spec)
-		case ParameterGit:
-			value, err = NewGit(param.Description, param.Required, param.Spec, git.Get)
-		default:
-			return conf, fmt.Errorf("unknown parameter type %s for parameter %s", param.Type, name)
-		}
-
+		value, err := newParameter(name, param)
 		if err != nil {
-			return conf, fmt.Errorf("cannot initialize parameter %s: %w", name, err)
+			return conf, err
 		}
 
 		conf.Parameters[name] = value
 	}
 
+	positional, err := parsePositional(raw.Positional)
+	if err != nil {
+		return conf, fmt.Errorf("cannot parse positional: %w", err)
+	}
+
+	conf.Positional = positional
+
 	return conf, nil
 }
+
+func newParameter(name string, param RawParameter) (Parameter, error) { //nolint: cyclop
+	var (
+		value Parameter
+		err   error
+	)
+
+	switch param.Type {
+	case ParameterInteger:
+		value, err = NewInteger(param.Description, param.Required, param.Spec)
+	case ParameterString:
+		value, err = NewString(param.Description, param.Required, param.Spec)
+	case ParameterFloat:
+		value, err = NewFloat(param.Description, param.Required, param.Spec)
+	case ParameterURL:
+		value, err = NewURL(param.Description, param.Required, param.Spec)
+	case ParameterEmail:
+		value, err = NewEmail(param.Description, param.Required, param.Spec)
+	case ParameterEnum:
+		value, err = NewEnum(param.Description, param.Required, param.Spec)
+	case ParameterBase64:
+		value, err = NewBase64(param.Description, param.Required, param.Spec)
+	case ParameterHex:
+		value, err = NewHex(param.Description, param.Required, param.Spec)
+	case ParameterHostname:
+		value, err = NewHostname(param.Description, param.Required, param.Spec)
+	case ParameterMac:
+		value, err = NewMac(param.Description, param.Required, param.Spec)
+	case ParameterJSON:
+		value, err = NewJSON(param.Description, param.Required, param.Spec)
+	case ParameterXML:
+		value, err = NewXML(param.Description, param.Required, param.Spec)
+	case ParameterUUID:
+		value, err = NewUUID(param.Description, param.Required, param.Spec)
+	case ParameterDirectory:
+		value, err = NewDirectory(param.Description, param.Required, param.Spec)
+	case ParameterFile:
+		value, err = NewFile(param.Description, param.Required, param.Spec)
+	case ParameterSemver:
+		value, err = NewSemver(param.Description, param.Required, param.Spec)
+	case ParameterDatetime:
+		value, err = NewDatetime(param.Description, param.Required, param.Spec)
+	case ParameterGit:
+		value, err = NewGit(param.Description, param.Required, param.Spec, git.Get)
+	default:
+		return nil, fmt.Errorf("unknown parameter type %s for parameter %s", param.Type, name)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize parameter %s: %w", name, err)
+	}
+
+	if _, err := parseBool(param.Spec, SpecSensitive); err != nil {
+		return nil, fmt.Errorf("cannot initialize parameter %s: %w", name, err)
+	}
+
+	if _, err := makeListSpec(param.Spec); err != nil {
+		return nil, fmt.Errorf("cannot initialize parameter %s: %w", name, err)
+	}
+
+	value, err = parseParameterDefault(value, param.Default, param.DefaultList)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect default of parameter %s: %w", name, err)
+	}
+
+	return value, nil
+}
@@ -4,11 +4,15 @@
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	"github.com/9seconds/chore/internal/commands"
 	"github.com/9seconds/chore/internal/script/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -42,6 +46,358 @@ func (suite *ConfigTestSuite) TestParseDescription() {
 	suite.Equal("xxy", conf.Description)
 }
 
+func (suite *ConfigTestSuite) TestParseExecutionPolicy() {
+	buf := strings.NewReader(`
+timeout = "10m"
+stop_signal = "int"
+stop_grace_period = "30s"
+memoize = "1h"`)
+
+	conf, err := config.Parse(buf)
+	suite.NoError(err)
+	suite.Equal(time.Hour, conf.Memoize)
+	suite.Equal(10*time.Minute, conf.Timeout)
+	suite.Equal(syscall.SIGINT, conf.StopSignal)
+	suite.Equal(30*time.Second, conf.StopGracePeriod)
+}
+
+func (suite *ConfigTestSuite) TestParseDefaultExecutionPolicy() {
+	conf, err := config.Parse(strings.NewReader(""))
+	suite.NoError(err)
+	suite.Zero(conf.Timeout)
+	suite.Nil(conf.StopSignal)
+	suite.Zero(conf.StopGracePeriod)
+	suite.Zero(conf.Memoize)
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectExecutionPolicy() {
+	testTable := map[string]string{
+		`timeout = "x"`:             "cannot parse timeout",
+		`timeout = "-1s"`:           "cannot parse timeout",
+		`stop_signal = "xxx"`:       "cannot parse stop signal",
+		`stop_grace_period = "1"`:   "cannot parse stop grace period",
+		`stop_grace_period = "-1s"`: "cannot parse stop grace period",
+		`memoize = "1"`:             "cannot parse memoize",
+	}
+
+	for testValue, errMessage := range testTable {
+		testValue := testValue
+		errMessage := errMessage
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(testValue))
+			assert.ErrorContains(t, err, errMessage)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseLock() {
+	testTable := map[string]config.LockMode{
+		``:                  config.LockModeNone,
+		`lock = "none"`:     config.LockModeNone,
+		`lock = "script"`:   config.LockModeScript,
+		`lock = "isolated"`: config.LockModeIsolated,
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			conf, err := config.Parse(strings.NewReader(testValue))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, conf.Lock)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectLock() {
+	_, err := config.Parse(strings.NewReader(`lock = "xxx"`))
+	suite.ErrorIs(err, config.ErrInvalidLockMode)
+}
+
+func (suite *ConfigTestSuite) TestParseSchedule() {
+	now := time.Date(2023, 8, 1, 10, 7, 0, 0, time.Local)
+	testTable := map[string]struct {
+		spec string
+		next time.Time
+	}{
+		`schedule = "*/15 * * * *"`: {"*/15 * * * *", time.Date(2023, 8, 1, 10, 15, 0, 0, time.Local)},
+		`schedule = "@hourly"`:      {"@hourly", time.Date(2023, 8, 1, 11, 0, 0, 0, time.Local)},
+		`every = "10m"`:             {"@every 10m0s", now.Add(10 * time.Minute)},
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			conf, err := config.Parse(strings.NewReader(testValue))
+			assert.NoError(t, err)
+			assert.True(t, conf.Schedule.Enabled())
+			assert.Equal(t, expected.spec, conf.Schedule.Spec)
+			assert.Equal(t, expected.next, conf.Schedule.Next(now))
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseNoSchedule() {
+	conf, err := config.Parse(strings.NewReader(""))
+	suite.NoError(err)
+	suite.False(conf.Schedule.Enabled())
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectSchedule() {
+	testTable := map[string]string{
+		`schedule = "* *"`:                       "cannot parse schedule",
+		`every = "x"`:                            "cannot parse every",
+		`every = "0s"`:                           "every should be",
+		"schedule = \"@hourly\"\nevery = \"1m\"": "mutually exclusive",
+	}
+
+	for testValue, errMessage := range testTable {
+		testValue := testValue
+		errMessage := errMessage
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(testValue))
+			assert.ErrorContains(t, err, errMessage)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseLimits() {
+	buf := strings.NewReader(`
+[limits]
+memory = "1G"
+cpu_time = "1m"
+open_files = 1024
+processes = 100
+file_size = "10MB"
+core = "0"`)
+
+	conf, err := config.Parse(buf)
+	suite.NoError(err)
+	suite.Equal(commands.Limits{
+		Memory:    1 << 30,
+		CPUTime:   time.Minute,
+		OpenFiles: 1024,
+		Processes: 100,
+		FileSize:  10 << 20,
+	}, conf.Limits)
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectLimits() {
+	testTable := map[string]string{
+		`memory = "1X"`:    "cannot parse memory",
+		`cpu_time = "-1s"`: "cannot parse cpu_time",
+		`file_size = "xx"`: "cannot parse file_size",
+		`core = "-1"`:      "cannot parse core",
+		"open_files = -1":  "open_files should be",
+		"processes = -1":   "processes should be",
+	}
+
+	for testValue, errMessage := range testTable {
+		testValue := testValue
+		errMessage := errMessage
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader("[limits]\n" + testValue))
+			assert.ErrorContains(t, err, errMessage)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseEnvironment() {
+	conf, err := config.Parse(strings.NewReader(`
+[environment]
+inherit = "allowlist"
+allow = ["AWS_*"]
+deny = ["AWS_PROFILE"]`))
+	suite.NoError(err)
+	suite.Equal(config.Environment{
+		Inherit: config.InheritModeAllowlist,
+		Allow:   []string{"AWS_*"},
+		Deny:    []string{"AWS_PROFILE"},
+	}, conf.Environment)
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectEnvironment() {
+	testTable := map[string]string{
+		"inherit": `inherit = "some"`,
+		"allow":   `allow = ["[x"]`,
+		"deny":    `deny = ["[x"]`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader("[environment]\n" + testValue))
+			assert.ErrorContains(t, err, "cannot parse environment")
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseSecrets() {
+	conf, err := config.Parse(strings.NewReader(`
+[secrets]
+GITHUB_TOKEN = "github_token"`))
+	suite.NoError(err)
+	suite.Equal(map[string]string{"GITHUB_TOKEN": "github_token"}, conf.Secrets)
+	suite.Equal(config.ExpiredSecretsModeWarn, conf.ExpiredSecrets)
+}
+
+func (suite *ConfigTestSuite) TestParseSecretFiles() {
+	conf, err := config.Parse(strings.NewReader(`
+[secret_files]
+SSH_KEY = "ssh_key"`))
+	suite.NoError(err)
+	suite.Equal(map[string]string{"SSH_KEY": "ssh_key"}, conf.SecretFiles)
+
+	_, err = config.Parse(strings.NewReader(`
+[secrets]
+SSH_KEY = "token"
+
+[secret_files]
+SSH_KEY = "ssh_key"`))
+	suite.ErrorContains(err, "cannot parse secret_files")
+}
+
+func (suite *ConfigTestSuite) TestParseExpiredSecrets() {
+	conf, err := config.Parse(strings.NewReader(`expired_secrets = "fail"`))
+	suite.NoError(err)
+	suite.Equal(config.ExpiredSecretsModeFail, conf.ExpiredSecrets)
+
+	_, err = config.Parse(strings.NewReader(`expired_secrets = "xxx"`))
+	suite.ErrorIs(err, config.ErrInvalidExpiredSecretsMode)
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectSecrets() {
+	testTable := map[string]string{
+		"name": `"1TOKEN" = "key"`,
+		"key":  `TOKEN = ""`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader("[secrets]\n" + testValue))
+			assert.ErrorContains(t, err, "cannot parse secrets")
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseRetry() {
+	buf := strings.NewReader(`
+[retry]
+attempts = 5
+backoff = "exponential"
+delay = "2s"
+max_delay = "1m"
+on_exit_codes = [1, 75]`)
+
+	conf, err := config.Parse(buf)
+	suite.NoError(err)
+	suite.Equal(5, conf.Retry.Attempts)
+	suite.Equal(config.BackoffExponential, conf.Retry.Backoff)
+	suite.Equal(2*time.Second, conf.Retry.Delay)
+	suite.Equal(time.Minute, conf.Retry.MaxDelay)
+	suite.Equal([]int{1, 75}, conf.Retry.OnExitCodes)
+}
+
+func (suite *ConfigTestSuite) TestParseDefaultRetry() {
+	conf, err := config.Parse(strings.NewReader(""))
+	suite.NoError(err)
+	suite.Zero(conf.Retry.Attempts)
+	suite.Equal(config.BackoffConstant, conf.Retry.Backoff)
+	suite.Equal(config.RetryDefaultDelay, conf.Retry.Delay)
+	suite.False(conf.Retry.ShouldRetry(1, 1))
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectRetry() {
+	testTable := map[string]string{
+		"attempts = -1":      "attempts should be",
+		`backoff = "linear"`: "cannot parse backoff",
+		`delay = "1"`:        "cannot parse delay",
+		`max_delay = "-1s"`:  "cannot parse max_delay",
+	}
+
+	for testValue, errMessage := range testTable {
+		testValue := testValue
+		errMessage := errMessage
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader("[retry]\n" + testValue))
+			assert.ErrorContains(t, err, errMessage)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseCaptureOutput() {
+	buf := strings.NewReader(`
+[capture_output]
+enabled = true
+timestamps = true
+max_runs = 10
+max_size = "10 MB"`)
+
+	conf, err := config.Parse(buf)
+	suite.NoError(err)
+	suite.Equal(config.CaptureOutput{
+		Enabled:    true,
+		Timestamps: true,
+		MaxRuns:    10,
+		MaxSize:    10 * 1024 * 1024,
+	}, conf.CaptureOutput)
+}
+
+func (suite *ConfigTestSuite) TestParseCaptureOutputSize() {
+	testTable := map[string]int64{
+		"":      0,
+		"10":    10,
+		"10b":   10,
+		"1k":    1024,
+		"2KB":   2048,
+		"1m":    1024 * 1024,
+		"1g":    1024 * 1024 * 1024,
+		"1 TB":  1024 * 1024 * 1024 * 1024,
+		" 3 mb": 3 * 1024 * 1024,
+	}
+
+	for testValue, expected := range testTable {
+		testValue := testValue
+		expected := expected
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			conf, err := config.Parse(strings.NewReader(
+				fmt.Sprintf("capture_output = { max_size = %q }", testValue)))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, conf.CaptureOutput.MaxSize)
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectCaptureOutput() {
+	testTable := []string{
+		`capture_output = { max_size = "x" }`,
+		`capture_output = { max_size = "1PB" }`,
+		`capture_output = { max_size = "-1" }`,
+		`capture_output = { max_size = "100000000000T" }`,
+		`capture_output = { max_runs = -1 }`,
+	}
+
+	for _, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testValue, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(testValue))
+			assert.ErrorContains(t, err, "cannot parse capture_output")
+		})
+	}
+}
+
 func (suite *ConfigTestSuite) TestParameter() {
 	tableTest := []string{
 		config.ParameterInteger,
@@ -90,6 +446,227 @@ func (suite *ConfigTestSuite) TestParameter() {
 	}
 }
 
+func (suite *ConfigTestSuite) TestSensitiveParameter() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.secret]
+type = "string"
+spec = { sensitive = "true" }
+
+[parameters.plain]
+type = "string"`))
+	suite.NoError(err)
+	suite.True(conf.Parameters["secret"].Sensitive())
+	suite.False(conf.Parameters["plain"].Sensitive())
+}
+
+func (suite *ConfigTestSuite) TestIncorrectSensitiveParameter() {
+	_, err := config.Parse(strings.NewReader(`
+[parameters.secret]
+type = "string"
+spec = { sensitive = "xx" }`))
+	suite.ErrorContains(err, "cannot parse sensitive")
+}
+
+func (suite *ConfigTestSuite) TestParseDefaults() {
+	conf, err := config.Parse(strings.NewReader(`
+[flags.set]
+default = true
+
+[flags.clear]
+default = false
+
+[flags.none]
+
+[parameters.single]
+type = "integer"
+default = "1"
+
+[parameters.list]
+type = "integer"
+default_list = ["1", "2"]
+
+[parameters.none]
+type = "integer"`))
+	suite.NoError(err)
+
+	value, ok := conf.Flags["set"].Default()
+	suite.True(ok)
+	suite.True(value)
+
+	value, ok = conf.Flags["clear"].Default()
+	suite.True(ok)
+	suite.False(value)
+
+	_, ok = conf.Flags["none"].Default()
+	suite.False(ok)
+
+	suite.Equal([]string{"1"}, conf.Parameters["single"].Default())
+	suite.Equal([]string{"1", "2"}, conf.Parameters["list"].Default())
+	suite.Nil(conf.Parameters["none"].Default())
+	suite.Equal(config.ParameterInteger, conf.Parameters["list"].Type())
+}
+
+func (suite *ConfigTestSuite) TestParseEnumDefault() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.enum]
+type = "enum"
+spec = { choices = "a,b,c" }
+default = "b"`))
+	suite.NoError(err)
+
+	param := conf.Parameters["enum"]
+	suite.Equal([]string{"b"}, param.Default())
+
+	withChoices, ok := param.(config.ParameterWithChoices)
+	suite.True(ok)
+	suite.Equal([]string{"a", "b", "c"}, withChoices.Choices())
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectDefaults() {
+	testTable := map[string]string{
+		"invalid":      `default = "x"`,
+		"invalid list": `default_list = ["1", "x"]`,
+		"both":         `default = "1"` + "\n" + `default_list = ["1"]`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(`
+[parameters.param]
+type = "integer"
+` + testValue))
+			assert.ErrorContains(t, err, "incorrect default of parameter param")
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestParameterListSpec() {
+	conf, err := config.Parse(strings.NewReader(`
+[parameters.list]
+type = "string"
+spec = { min_count = "1", max_count = "3", unique = "true", keep_order = "true", split = "false" }
+
+[parameters.plain]
+type = "string"`))
+	suite.NoError(err)
+
+	suite.Equal(config.ListSpec{
+		MinCount:  1,
+		MaxCount:  3,
+		Unique:    true,
+		KeepOrder: true,
+		Split:     false,
+	}, conf.Parameters["list"].List())
+	suite.Equal(config.ListSpec{
+		MinCount: 0,
+		MaxCount: math.MaxInt,
+		Split:    true,
+	}, conf.Parameters["plain"].List())
+}
+
+func (suite *ConfigTestSuite) TestIncorrectParameterListSpec() {
+	testTable := map[string]string{
+		"min_count":  `{ min_count = "-1" }`,
+		"max_count":  `{ max_count = "x" }`,
+		"min > max":  `{ min_count = "3", max_count = "2" }`,
+		"unique":     `{ unique = "x" }`,
+		"keep_order": `{ keep_order = "x" }`,
+		"split":      `{ split = "x" }`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(`
+[parameters.param]
+type = "string"
+spec = ` + testValue))
+			assert.ErrorContains(t, err, "cannot initialize parameter param")
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestDefaultListSpec() {
+	_, err := config.Parse(strings.NewReader(`
+[parameters.param]
+type = "string"
+default = "x"
+spec = { min_count = "2" }`))
+	suite.ErrorContains(err, "number of values must be >= 2")
+}
+
+func (suite *ConfigTestSuite) TestParsePositional() {
+	conf, err := config.Parse(strings.NewReader(`
+[[positional]]
+name = "Host-Name"
+type = "string"
+required = true
+description = "host to connect to"
+
+[[positional]]
+name = "command"
+type = "string"
+variadic = true`))
+	suite.NoError(err)
+	suite.Len(conf.Positional, 2)
+
+	suite.Equal("host_name", conf.Positional[0].Name)
+	suite.True(conf.Positional[0].Parameter.Required())
+	suite.False(conf.Positional[0].Variadic)
+	suite.Equal("host to connect to", conf.Positional[0].Parameter.Description())
+
+	suite.Equal("command", conf.Positional[1].Name)
+	suite.False(conf.Positional[1].Parameter.Required())
+	suite.True(conf.Positional[1].Variadic)
+}
+
+func (suite *ConfigTestSuite) TestParseIncorrectPositional() {
+	testTable := map[string]string{
+		"no name": `
+[[positional]]
+type = "string"`,
+		"duplicate": `
+[[positional]]
+name = "x"
+type = "string"
+[[positional]]
+name = "x"
+type = "string"`,
+		"variadic is not last": `
+[[positional]]
+name = "x"
+type = "string"
+variadic = true
+[[positional]]
+name = "y"
+type = "string"`,
+		"required after optional": `
+[[positional]]
+name = "x"
+type = "string"
+[[positional]]
+name = "y"
+type = "string"
+required = true`,
+		"unknown type": `
+[[positional]]
+name = "x"
+type = "xxx"`,
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := config.Parse(strings.NewReader(testValue))
+			assert.ErrorContains(t, err, "cannot parse positional")
+		})
+	}
+}
+
 func (suite *ConfigTestSuite) TestUnknownParameterType() {
 	configRaw := `
 [parameters.param]
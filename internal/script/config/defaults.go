@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrDefaultConflict = errors.New("default and default_list are mutually exclusive")
+
+type paramWithDefault struct {
+	Parameter
+
+	defaults []string
+}
+
+func (p paramWithDefault) Default() []string {
+	return p.defaults
+}
+
+// choicesWithDefault keeps choices of a parameter visible: embedding
+// of Parameter interface hides them.
+type choicesWithDefault struct {
+	paramWithDefault
+
+	choices ParameterWithChoices
+}
+
+func (c choicesWithDefault) Choices() []string {
+	return c.choices.Choices()
+}
+
+type flagWithDefault struct {
+	Flag
+
+	value bool
+}
+
+func (f flagWithDefault) Default() (bool, bool) {
+	return f.value, true
+}
+
+func parseParameterDefault(param Parameter, value *string, values []string) (Parameter, error) {
+	switch {
+	case value != nil && len(values) > 0:
+		return nil, ErrDefaultConflict
+	case value != nil:
+		values = []string{*value}
+	case len(values) == 0:
+		return param, nil
+	}
+
+	for _, v := range values {
+		if err := param.Validate(context.Background(), v); err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", v, err)
+		}
+	}
+
+	if err := param.List().Validate(values); err != nil {
+		return nil, err
+	}
+
+	withDefault := paramWithDefault{
+		Parameter: param,
+		defaults:  values,
+	}
+
+	if withChoices, ok := param.(ParameterWithChoices); ok {
+		return choicesWithDefault{
+			paramWithDefault: withDefault,
+			choices:          withChoices,
+		}, nil
+	}
+
+	return withDefault, nil
+}
+
+func parseFlagDefault(flag Flag, value *bool) Flag {
+	if value == nil {
+		return flag
+	}
+
+	return flagWithDefault{
+		Flag:  flag,
+		value: *value,
+	}
+}
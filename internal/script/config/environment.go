@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+type InheritMode string
+
+const (
+	InheritModeAll       InheritMode = "all"
+	InheritModeNone      InheritMode = "none"
+	InheritModeAllowlist InheritMode = "allowlist"
+)
+
+var ErrInvalidInheritMode = errors.New("invalid inherit mode")
+
+// EssentialVariables are always inherited from a parent environment,
+// regardless of inherit mode and deny list. Values are glob patterns.
+var EssentialVariables = []string{
+	"PATH",
+	"HOME",
+	"USER",
+	"LOGNAME",
+	"SHELL",
+	"TERM",
+	"TMPDIR",
+	"LANG",
+	"LC_*",
+}
+
+func (i InheritMode) String() string {
+	return string(i)
+}
+
+func (i InheritMode) Valid() bool {
+	switch i {
+	case InheritModeAll, InheritModeNone, InheritModeAllowlist:
+		return true
+	}
+
+	return false
+}
+
+func GetInheritMode(value string) (InheritMode, error) {
+	if value == "" {
+		value = InheritModeAll.String()
+	}
+
+	mode := InheritMode(value)
+
+	if !mode.Valid() {
+		return "", ErrInvalidInheritMode
+	}
+
+	return mode, nil
+}
+
+// Environment defines which variables of a parent environment are
+// passed to the script.
+type Environment struct {
+	Inherit InheritMode
+	Allow   []string
+	Deny    []string
+}
+
+// Filter returns those values of KEY=VALUE environment which should be
+// inherited.
+func (e Environment) Filter(environ []string) []string {
+	filtered := make([]string, 0, len(environ))
+
+	for _, value := range environ {
+		name, _, _ := strings.Cut(value, "=")
+
+		if e.Inherits(name) {
+			filtered = append(filtered, value)
+		}
+	}
+
+	return filtered
+}
+
+// Inherits tells if a variable with a given name should be inherited.
+func (e Environment) Inherits(name string) bool {
+	switch {
+	case matchGlobs(EssentialVariables, name):
+		return true
+	case matchGlobs(e.Deny, name):
+		return false
+	}
+
+	switch e.Inherit {
+	case InheritModeNone:
+		return false
+	case InheritModeAllowlist:
+		return matchGlobs(e.Allow, name)
+	}
+
+	return true
+}
+
+func matchGlobs(globs []string, name string) bool {
+	for _, glob := range globs {
+		// patterns are validated on parsing
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseEnvironment(raw RawEnvironment) (Environment, error) {
+	mode, err := GetInheritMode(raw.Inherit)
+	if err != nil {
+		return Environment{}, err
+	}
+
+	for _, glob := range append(append([]string{}, raw.Allow...), raw.Deny...) {
+		if _, err := path.Match(glob, ""); err != nil {
+			return Environment{}, fmt.Errorf("incorrect pattern %q: %w", glob, err)
+		}
+	}
+
+	return Environment{
+		Inherit: mode,
+		Allow:   raw.Allow,
+		Deny:    raw.Deny,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/9seconds/chore/internal/script/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EnvironmentTestSuite struct {
+	suite.Suite
+
+	environ []string
+}
+
+func (suite *EnvironmentTestSuite) SetupTest() {
+	suite.environ = []string{
+		"PATH=/bin",
+		"HOME=/home/user",
+		"LC_ALL=C",
+		"AWS_PROFILE=prod",
+		"AWS_REGION=eu-west-1",
+		"GIT_DIR=/tmp",
+		"EMPTY=",
+	}
+}
+
+func (suite *EnvironmentTestSuite) TestFilter() {
+	testTable := map[string]struct {
+		environment config.Environment
+		expected    []string
+	}{
+		"default": {
+			environment: config.Environment{},
+			expected:    suite.environ,
+		},
+		"all with deny": {
+			environment: config.Environment{
+				Inherit: config.InheritModeAll,
+				Deny:    []string{"AWS_*", "PATH"},
+			},
+			expected: []string{"PATH=/bin", "HOME=/home/user", "LC_ALL=C", "GIT_DIR=/tmp", "EMPTY="},
+		},
+		"none": {
+			environment: config.Environment{
+				Inherit: config.InheritModeNone,
+				Allow:   []string{"GIT_DIR"},
+			},
+			expected: []string{"PATH=/bin", "HOME=/home/user", "LC_ALL=C"},
+		},
+		"allowlist": {
+			environment: config.Environment{
+				Inherit: config.InheritModeAllowlist,
+				Allow:   []string{"AWS_*", "EMPTY"},
+				Deny:    []string{"AWS_PROFILE"},
+			},
+			expected: []string{"PATH=/bin", "HOME=/home/user", "LC_ALL=C", "AWS_REGION=eu-west-1", "EMPTY="},
+		},
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			assert.Equal(t, testValue.expected, testValue.environment.Filter(suite.environ))
+		})
+	}
+}
+
+func (suite *EnvironmentTestSuite) TestGetInheritMode() {
+	mode, err := config.GetInheritMode("")
+	suite.NoError(err)
+	suite.Equal(config.InheritModeAll, mode)
+
+	mode, err = config.GetInheritMode("allowlist")
+	suite.NoError(err)
+	suite.Equal(config.InheritModeAllowlist, mode)
+
+	_, err = config.GetInheritMode("some")
+	suite.ErrorIs(err, config.ErrInvalidInheritMode)
+}
+
+func TestEnvironment(t *testing.T) {
+	suite.Run(t, &EnvironmentTestSuite{})
+}
@@ -5,6 +5,7 @@
 type Flag interface {
 	Required() bool
 	Description() string
+	Default() (bool, bool)
 	String() string
 }
 
@@ -21,6 +22,12 @@ func (b baseFlag) Description() string {
 	return b.description
 }
 
+// Default returns a value which is used if flag is not provided and
+// tells if this value is defined at all.
+func (b baseFlag) Default() (bool, bool) {
+	return false, false
+}
+
 func (b baseFlag) String() string {
 	return fmt.Sprintf("%q (required=%t)", b.description, b.required)
 }
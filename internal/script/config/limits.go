@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/9seconds/chore/internal/commands"
+)
+
+var (
+	errIncorrectOpenFiles = errors.New("open_files should be >= 0")
+	errIncorrectProcesses = errors.New("processes should be >= 0")
+)
+
+func parseLimits(raw RawLimits) (commands.Limits, error) {
+	memory, err := parseSize(raw.Memory)
+	if err != nil {
+		return commands.Limits{}, fmt.Errorf("cannot parse memory: %w", err)
+	}
+
+	cpuTime, err := parseDuration(raw.CPUTime)
+	if err != nil {
+		return commands.Limits{}, fmt.Errorf("cannot parse cpu_time: %w", err)
+	}
+
+	fileSize, err := parseSize(raw.FileSize)
+	if err != nil {
+		return commands.Limits{}, fmt.Errorf("cannot parse file_size: %w", err)
+	}
+
+	core, err := parseSize(raw.Core)
+	if err != nil {
+		return commands.Limits{}, fmt.Errorf("cannot parse core: %w", err)
+	}
+
+	if raw.OpenFiles < 0 {
+		return commands.Limits{}, errIncorrectOpenFiles
+	}
+
+	if raw.Processes < 0 {
+		return commands.Limits{}, errIncorrectProcesses
+	}
+
+	return commands.Limits{
+		Memory:    uint64(memory),
+		CPUTime:   cpuTime,
+		OpenFiles: uint64(raw.OpenFiles),
+		Processes: uint64(raw.Processes),
+		FileSize:  uint64(fileSize),
+		Core:      uint64(core),
+	}, nil
+}
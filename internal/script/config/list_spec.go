@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	SpecMinCount  = "min_count"
+	SpecMaxCount  = "max_count"
+	SpecUnique    = "unique"
+	SpecKeepOrder = "keep_order"
+	SpecSplit     = "split"
+)
+
+// ListSpec defines how repeated values of a parameter are treated.
+type ListSpec struct {
+	MinCount int
+	MaxCount int
+	// Unique prohibits repeated values.
+	Unique bool
+	// KeepOrder keeps values in order they were given instead of
+	// sorting them.
+	KeepOrder bool
+	// Split tells that values have to be shlex-splitted. Otherwise
+	// they are taken literally.
+	Split bool
+}
+
+func (l ListSpec) Validate(values []string) error {
+	switch {
+	case len(values) < l.MinCount:
+		return fmt.Errorf("number of values must be >= %d", l.MinCount)
+	case len(values) > l.MaxCount:
+		return fmt.Errorf("number of values must be <= %d", l.MaxCount)
+	}
+
+	if l.Unique {
+		seen := make(map[string]struct{}, len(values))
+
+		for _, v := range values {
+			if _, ok := seen[v]; ok {
+				return fmt.Errorf("value %q is repeated", v)
+			}
+
+			seen[v] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+func makeListSpec(spec map[string]string) (ListSpec, error) {
+	rValue := ListSpec{
+		MinCount: 0,
+		MaxCount: math.MaxInt,
+		Split:    true,
+	}
+
+	if min, ok := spec[SpecMinCount]; ok {
+		value, err := strconv.ParseUint(min, 10, 32)
+		if err != nil {
+			return rValue, fmt.Errorf("incorrect '%s' value: %w", SpecMinCount, err)
+		}
+
+		rValue.MinCount = int(value)
+	}
+
+	if max, ok := spec[SpecMaxCount]; ok {
+		value, err := strconv.ParseUint(max, 10, 32)
+		if err != nil {
+			return rValue, fmt.Errorf("incorrect '%s' value: %w", SpecMaxCount, err)
+		}
+
+		rValue.MaxCount = int(value)
+	}
+
+	if rValue.MaxCount < rValue.MinCount {
+		return rValue, fmt.Errorf(
+			"min count %d should be <= max count %d",
+			rValue.MinCount,
+			rValue.MaxCount)
+	}
+
+	unique, err := parseBool(spec, SpecUnique)
+	if err != nil {
+		return rValue, err
+	}
+
+	keepOrder, err := parseBool(spec, SpecKeepOrder)
+	if err != nil {
+		return rValue, err
+	}
+
+	rValue.Unique = unique
+	rValue.KeepOrder = keepOrder
+
+	if _, ok := spec[SpecSplit]; ok {
+		split, err := parseBool(spec, SpecSplit)
+		if err != nil {
+			return rValue, err
+		}
+
+		rValue.Split = split
+	}
+
+	return rValue, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import "errors"
+
+type LockMode string
+
+const (
+	LockModeNone     LockMode = "none"
+	LockModeScript   LockMode = "script"
+	LockModeIsolated LockMode = "isolated"
+)
+
+var ErrInvalidLockMode = errors.New("invalid lock mode")
+
+func (l LockMode) String() string {
+	return string(l)
+}
+
+func (l LockMode) Valid() bool {
+	switch l {
+	case LockModeNone, LockModeScript, LockModeIsolated:
+		return true
+	}
+
+	return false
+}
+
+func GetLockMode(value string) (LockMode, error) {
+	if value == "" {
+		value = LockModeNone.String()
+	}
+
+	mode := LockMode(value)
+
+	if !mode.Valid() {
+		return "", ErrInvalidLockMode
+	}
+
+	return mode, nil
+}
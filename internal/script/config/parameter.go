@@ -2,14 +2,27 @@
 
 import "context"
 
+const SpecSensitive = "sensitive"
+
 type Parameter interface {
 	Specification() map[string]string
 	Description() string
 	Type() string
 	Required() bool
+	Sensitive() bool
+	Default() []string
+	List() ListSpec
 	Validate(context.Context, string) error
 }
 
+// ParameterWithChoices is a parameter which accepts only a fixed set of
+// values.
+type ParameterWithChoices interface {
+	Parameter
+
+	Choices() []string
+}
+
 type baseParameter struct {
 	required      bool
 	description   string
@@ -20,6 +33,27 @@ func (b baseParameter) Required() bool {
 	return b.required
 }
 
+// Sensitive tells that values of the parameter should not be shown
+// while typed and should be masked where they are shown or stored.
+func (b baseParameter) Sensitive() bool {
+	sensitive, _ := parseBool(b.specification, SpecSensitive)
+
+	return sensitive
+}
+
+// List returns rules for repeated values of the parameter.
+func (b baseParameter) List() ListSpec {
+	spec, _ := makeListSpec(b.specification)
+
+	return spec
+}
+
+// Default returns values which are used if parameter is not provided.
+// nil means there is no default.
+func (b baseParameter) Default() []string {
+	return nil
+}
+
 func (b baseParameter) Description() string {
 	return b.description
 }
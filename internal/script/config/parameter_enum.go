@@ -3,6 +3,8 @@
 import (
 	"context"
 	"errors"
+
+	"github.com/9seconds/chore/internal/binutils"
 )
 
 var (
@@ -22,6 +24,10 @@ func (p paramEnum) Type() string {
 	return ParameterEnum
 }
 
+func (p paramEnum) Choices() []string {
+	return binutils.SortedMapKeys(p.choices)
+}
+
 func (p paramEnum) Validate(_ context.Context, value string) error {
 	if _, ok := p.choices[value]; !ok {
 		return errInvalidChoice
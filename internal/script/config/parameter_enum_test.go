@@ -44,6 +44,16 @@ func (suite *ParameterEnumTestSuite) TestType() {
 	suite.Equal(config.ParameterEnum, param.Type())
 }
 
+func (suite *ParameterEnumTestSuite) TestChoices() {
+	param, err := config.NewEnum("", false, map[string]string{
+		"choices": "zz, xx,yy",
+	})
+	suite.NoError(err)
+	suite.Equal(
+		[]string{"xx", "yy", "zz"},
+		param.(config.ParameterWithChoices).Choices())
+}
+
 func (suite *ParameterEnumTestSuite) TestNoChoices() {
 	_, err := config.NewEnum("", false, map[string]string{
 		"choices": ",,,,",
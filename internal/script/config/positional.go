@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrPositionalNoName          = errors.New("name is not defined")
+	ErrPositionalVariadicNotLast = errors.New("only the last positional argument can be variadic")
+	ErrPositionalRequiredOrder   = errors.New("required positional argument cannot follow an optional one")
+)
+
+// Positional describes a positional argument of the script.
+type Positional struct {
+	Name      string
+	Parameter Parameter
+	// Variadic positional consumes all remaining arguments.
+	Variadic bool
+}
+
+func parsePositional(raw []RawPositional) ([]Positional, error) {
+	positional := make([]Positional, 0, len(raw))
+	names := make(map[string]struct{}, len(raw))
+	seenOptional := false
+
+	for idx, arg := range raw {
+		name := NormalizeName(arg.Name)
+
+		switch _, ok := names[name]; {
+		case name == "":
+			return nil, fmt.Errorf("positional %d: %w", idx+1, ErrPositionalNoName)
+		case ok:
+			return nil, fmt.Errorf("positional %s is defined more than once", name)
+		case arg.Variadic && idx != len(raw)-1:
+			return nil, fmt.Errorf("positional %s: %w", name, ErrPositionalVariadicNotLast)
+		case arg.Required && seenOptional:
+			return nil, fmt.Errorf("positional %s: %w", name, ErrPositionalRequiredOrder)
+		}
+
+		param, err := newParameter(name, RawParameter{
+			Type:        arg.Type,
+			Required:    arg.Required,
+			Description: arg.Description,
+			Spec:        arg.Spec,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("positional %s: %w", name, err)
+		}
+
+		names[name] = struct{}{}
+		seenOptional = seenOptional || !arg.Required
+		positional = append(positional, Positional{
+			Name:      name,
+			Parameter: param,
+			Variadic:  arg.Variadic,
+		})
+	}
+
+	return positional, nil
+}
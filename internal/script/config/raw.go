@@ -8,11 +8,56 @@
 )
 
 type RawConfig struct {
-	Description string                  `toml:"description"`
-	Git         string                  `toml:"git"`
-	Network     bool                    `toml:"network"`
-	Parameters  map[string]RawParameter `toml:"parameters"`
-	Flags       map[string]RawFlag      `toml:"flags"`
+	Description     string                  `toml:"description"`
+	Git             string                  `toml:"git"`
+	Network         bool                    `toml:"network"`
+	Lock            string                  `toml:"lock"`
+	Timeout         string                  `toml:"timeout"`
+	StopSignal      string                  `toml:"stop_signal"`
+	StopGracePeriod string                  `toml:"stop_grace_period"`
+	Memoize         string                  `toml:"memoize"`
+	Schedule        string                  `toml:"schedule"`
+	Every           string                  `toml:"every"`
+	CaptureOutput   RawCaptureOutput        `toml:"capture_output"`
+	Retry           RawRetry                `toml:"retry"`
+	Limits          RawLimits               `toml:"limits"`
+	Environment     RawEnvironment          `toml:"environment"`
+	Secrets         map[string]string       `toml:"secrets"`
+	SecretFiles     map[string]string       `toml:"secret_files"`
+	ExpiredSecrets  string                  `toml:"expired_secrets"`
+	Parameters      map[string]RawParameter `toml:"parameters"`
+	Flags           map[string]RawFlag      `toml:"flags"`
+	Positional      []RawPositional         `toml:"positional"`
+}
+
+type RawCaptureOutput struct {
+	Enabled    bool   `toml:"enabled"`
+	Timestamps bool   `toml:"timestamps"`
+	MaxRuns    int    `toml:"max_runs"`
+	MaxSize    string `toml:"max_size"`
+}
+
+type RawRetry struct {
+	Attempts    int    `toml:"attempts"`
+	Backoff     string `toml:"backoff"`
+	Delay       string `toml:"delay"`
+	MaxDelay    string `toml:"max_delay"`
+	OnExitCodes []int  `toml:"on_exit_codes"`
+}
+
+type RawLimits struct {
+	Memory    string `toml:"memory"`
+	CPUTime   string `toml:"cpu_time"`
+	OpenFiles int    `toml:"open_files"`
+	Processes int    `toml:"processes"`
+	FileSize  string `toml:"file_size"`
+	Core      string `toml:"core"`
+}
+
+type RawEnvironment struct {
+	Inherit string   `toml:"inherit"`
+	Allow   []string `toml:"allow"`
+	Deny    []string `toml:"deny"`
 }
 
 type RawParameter struct {
@@ -20,11 +65,23 @@ type RawParameter struct {
 	Required    bool              `toml:"required"`
 	Description string            `toml:"description"`
 	Spec        map[string]string `toml:"spec"`
+	Default     *string           `toml:"default"`
+	DefaultList []string          `toml:"default_list"`
+}
+
+type RawPositional struct {
+	Name        string            `toml:"name"`
+	Type        string            `toml:"type"`
+	Required    bool              `toml:"required"`
+	Variadic    bool              `toml:"variadic"`
+	Description string            `toml:"description"`
+	Spec        map[string]string `toml:"spec"`
 }
 
 type RawFlag struct {
 	Required    bool   `toml:"required"`
 	Description string `toml:"description"`
+	Default     *bool  `toml:"default"`
 }
 
 func parseRaw(reader io.Reader) (RawConfig, error) {
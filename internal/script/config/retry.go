@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type Backoff string
+
+const (
+	BackoffConstant    Backoff = "constant"
+	BackoffExponential Backoff = "exponential"
+
+	RetryDefaultDelay = time.Second
+
+	maxExponentialDelay = time.Duration(math.MaxInt64 / 2)
+)
+
+var (
+	ErrInvalidBackoff = errors.New("invalid backoff")
+
+	errIncorrectAttempts = errors.New("attempts should be >= 0")
+)
+
+func (b Backoff) String() string {
+	return string(b)
+}
+
+func (b Backoff) Valid() bool {
+	switch b {
+	case BackoffConstant, BackoffExponential:
+		return true
+	}
+
+	return false
+}
+
+func GetBackoff(value string) (Backoff, error) {
+	if value == "" {
+		value = BackoffConstant.String()
+	}
+
+	backoff := Backoff(value)
+
+	if !backoff.Valid() {
+		return "", ErrInvalidBackoff
+	}
+
+	return backoff, nil
+}
+
+type Retry struct {
+	// Attempts is a maximal number of script executions including the
+	// first one. 0 and 1 mean that script is never retried.
+	Attempts int
+
+	Backoff  Backoff
+	Delay    time.Duration
+	MaxDelay time.Duration
+
+	// OnExitCodes is a list of exit codes to retry on. Empty list
+	// means any non-zero exit code.
+	OnExitCodes []int
+}
+
+// ShouldRetry reports if script has to be executed again after a given
+// attempt has finished with exitCode. Attempts are counted from 1.
+func (r Retry) ShouldRetry(attempt, exitCode int) bool {
+	if exitCode == 0 || attempt >= r.Attempts {
+		return false
+	}
+
+	if len(r.OnExitCodes) == 0 {
+		return true
+	}
+
+	for _, code := range r.OnExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextDelay returns how long to wait after a given failed attempt.
+func (r Retry) NextDelay(attempt int) time.Duration {
+	delay := r.Delay
+
+	if r.Backoff == BackoffExponential {
+		for i := 1; i < attempt && delay < maxExponentialDelay; i++ {
+			delay *= 2
+		}
+	}
+
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+
+	return delay
+}
+
+func parseRetry(raw RawRetry) (Retry, error) {
+	if raw.Attempts < 0 {
+		return Retry{}, errIncorrectAttempts
+	}
+
+	backoff, err := GetBackoff(raw.Backoff)
+	if err != nil {
+		return Retry{}, fmt.Errorf("cannot parse backoff: %w", err)
+	}
+
+	delay := RetryDefaultDelay
+
+	if raw.Delay != "" {
+		delay, err = parseDuration(raw.Delay)
+		if err != nil {
+			return Retry{}, fmt.Errorf("cannot parse delay: %w", err)
+		}
+	}
+
+	maxDelay, err := parseDuration(raw.MaxDelay)
+	if err != nil {
+		return Retry{}, fmt.Errorf("cannot parse max_delay: %w", err)
+	}
+
+	return Retry{
+		Attempts:    raw.Attempts,
+		Backoff:     backoff,
+		Delay:       delay,
+		MaxDelay:    maxDelay,
+		OnExitCodes: raw.OnExitCodes,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/script/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RetryTestSuite) TestShouldRetry() {
+	retry := config.Retry{
+		Attempts: 3,
+	}
+
+	suite.True(retry.ShouldRetry(1, 1))
+	suite.True(retry.ShouldRetry(2, 255))
+	suite.False(retry.ShouldRetry(3, 1))
+	suite.False(retry.ShouldRetry(1, 0))
+
+	retry.OnExitCodes = []int{75}
+
+	suite.True(retry.ShouldRetry(1, 75))
+	suite.False(retry.ShouldRetry(1, 1))
+}
+
+func (suite *RetryTestSuite) TestNextDelay() {
+	testTable := map[string]struct {
+		retry    config.Retry
+		attempt  int
+		expected time.Duration
+	}{
+		"constant": {
+			retry:    config.Retry{Backoff: config.BackoffConstant, Delay: time.Second},
+			attempt:  5,
+			expected: time.Second,
+		},
+		"exponential": {
+			retry:    config.Retry{Backoff: config.BackoffExponential, Delay: time.Second},
+			attempt:  4,
+			expected: 8 * time.Second,
+		},
+		"exponential-capped": {
+			retry: config.Retry{
+				Backoff:  config.BackoffExponential,
+				Delay:    time.Second,
+				MaxDelay: 5 * time.Second,
+			},
+			attempt:  4,
+			expected: 5 * time.Second,
+		},
+		"exponential-overflow": {
+			retry:    config.Retry{Backoff: config.BackoffExponential, Delay: time.Second},
+			attempt:  100,
+			expected: 8589934592 * time.Second,
+		},
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			assert.Equal(t, testValue.expected, testValue.retry.NextDelay(testValue.attempt))
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, &RetryTestSuite{})
+}
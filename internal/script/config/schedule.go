@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	errScheduleAndEvery = errors.New("schedule and every are mutually exclusive")
+	errIncorrectEvery   = errors.New("every should be > 0")
+)
+
+// Schedule defines when a script has to be executed by scheduler.
+type Schedule struct {
+	// Spec is a cron expression or a descriptor like @every 10m.
+	Spec string
+
+	schedule cron.Schedule
+}
+
+func (s Schedule) Enabled() bool {
+	return s.schedule != nil
+}
+
+// Next returns the next time after t when script has to be executed.
+func (s Schedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t)
+}
+
+func parseSchedule(schedule, every string) (Schedule, error) {
+	switch {
+	case schedule != "" && every != "":
+		return Schedule{}, errScheduleAndEvery
+	case every != "":
+		duration, err := parseDuration(every)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("cannot parse every: %w", err)
+		}
+
+		if duration == 0 {
+			return Schedule{}, errIncorrectEvery
+		}
+
+		schedule = "@every " + duration.String()
+	case schedule == "":
+		return Schedule{}, nil
+	}
+
+	parsed, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("cannot parse schedule: %w", err)
+	}
+
+	return Schedule{
+		Spec:     schedule,
+		schedule: parsed,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ExpiredSecretsMode defines what to do if a script uses a secret which
+// has expired.
+type ExpiredSecretsMode string
+
+const (
+	ExpiredSecretsModeWarn   ExpiredSecretsMode = "warn"
+	ExpiredSecretsModeFail   ExpiredSecretsMode = "fail"
+	ExpiredSecretsModeIgnore ExpiredSecretsMode = "ignore"
+)
+
+var (
+	ErrInvalidExpiredSecretsMode = errors.New("invalid expired secrets mode")
+
+	secretNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+func (e ExpiredSecretsMode) String() string {
+	return string(e)
+}
+
+func (e ExpiredSecretsMode) Valid() bool {
+	switch e {
+	case ExpiredSecretsModeWarn, ExpiredSecretsModeFail, ExpiredSecretsModeIgnore:
+		return true
+	}
+
+	return false
+}
+
+func GetExpiredSecretsMode(value string) (ExpiredSecretsMode, error) {
+	if value == "" {
+		value = ExpiredSecretsModeWarn.String()
+	}
+
+	mode := ExpiredSecretsMode(value)
+
+	if !mode.Valid() {
+		return "", ErrInvalidExpiredSecretsMode
+	}
+
+	return mode, nil
+}
+
+// parseSecrets validates a mapping of environment variable names to
+// keys of a namespace vault.
+func parseSecrets(raw map[string]string) (map[string]string, error) {
+	secrets := make(map[string]string, len(raw))
+
+	for name, key := range raw {
+		if !secretNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("incorrect environment variable name %q", name)
+		}
+
+		if key == "" {
+			return nil, fmt.Errorf("empty vault key for %s", name)
+		}
+
+		secrets[name] = key
+	}
+
+	return secrets, nil
+}
+
+// parseSecretFiles validates a mapping of environment variable names to
+// keys of a namespace vault which have to be written to files. Names
+// must not clash with secrets.
+func parseSecretFiles(raw, secrets map[string]string) (map[string]string, error) {
+	secretFiles, err := parseSecrets(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range secretFiles {
+		if _, ok := secrets[name]; ok {
+			return nil, fmt.Errorf("%s is already defined in secrets", name)
+		}
+	}
+
+	return secretFiles, nil
+}
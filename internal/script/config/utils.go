@@ -2,6 +2,7 @@
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,6 +10,19 @@
 	"unicode"
 )
 
+var sizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"K":  1 << 10,
+	"KB": 1 << 10,
+	"M":  1 << 20,
+	"MB": 1 << 20,
+	"G":  1 << 30,
+	"GB": 1 << 30,
+	"T":  1 << 40,
+	"TB": 1 << 40,
+}
+
 func NormalizeName(name string) string {
 	return strings.Map(func(char rune) rune {
 		switch {
@@ -77,3 +91,48 @@ func parseDurationNegative(spec map[string]string, name string) (time.Duration,
 
 	return -1, nil
 }
+
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("incorrect duration: %w", err)
+	}
+
+	if parsed < 0 {
+		return 0, fmt.Errorf("duration %s should be >=0", parsed)
+	}
+
+	return parsed, nil
+}
+
+// parseSize parses human-readable size like 10MB or 512k into a
+// number of bytes. Units are powers of 1024.
+func parseSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, nil
+	}
+
+	number := strings.TrimRightFunc(value, unicode.IsLetter)
+	unit := strings.TrimSpace(value[len(number):])
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit %s", unit)
+	}
+
+	parsed, err := strconv.ParseUint(strings.TrimSpace(number), 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("incorrect size: %w", err)
+	}
+
+	if parsed > uint64(math.MaxInt64/multiplier) {
+		return 0, fmt.Errorf("size %s is too big", value)
+	}
+
+	return int64(parsed) * multiplier, nil
+}
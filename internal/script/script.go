@@ -3,6 +3,8 @@
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/9seconds/chore/internal/argparse"
@@ -13,6 +15,8 @@
 	"github.com/gosimple/slug"
 )
 
+const ScriptLockName = "script"
+
 type Script struct {
 	Namespace  string
 	Executable string
@@ -43,19 +47,70 @@ func (s *Script) CachePath() string {
 	return paths.CacheNamespaceScript(s.Namespace, s.Executable)
 }
 
+func (s *Script) MemoizePath() string {
+	return paths.CacheNamespaceScriptMemoize(s.Namespace, s.Executable)
+}
+
 func (s *Script) StatePath() string {
 	return paths.StateNamespaceScript(s.Namespace, s.Executable)
 }
 
+// DotenvPaths returns paths of dotenv files of the script in order of
+// their precedence: namespace-wide one goes first.
+func (s *Script) DotenvPaths() []string {
+	return []string{
+		paths.ConfigNamespaceDotenv(s.Namespace),
+		paths.ConfigNamespaceScriptDotenv(s.Namespace, s.Executable),
+	}
+}
+
+func (s *Script) HistoryPath() string {
+	return paths.StateNamespaceScriptHistory(s.Namespace, s.Executable)
+}
+
+func (s *Script) LogsPath() string {
+	return paths.StateNamespaceScriptLogs(s.Namespace, s.Executable)
+}
+
+func (s *Script) LocksPath() string {
+	return paths.StateNamespaceScriptLocks(s.Namespace, s.Executable)
+}
+
+// LockPath returns a path to the lock file which has to be taken before
+// script is executed with given arguments. Empty string means that
+// script does not require any locking.
+func (s *Script) LockPath(args argparse.ParsedArgs) string {
+	switch s.Config.Lock {
+	case config.LockModeScript:
+		return filepath.Join(s.LocksPath(), ScriptLockName)
+	case config.LockModeIsolated:
+		return filepath.Join(s.LocksPath(), env.IsolatedID(s.Path(), args))
+	}
+
+	return ""
+}
+
 func (s *Script) TempPath() string {
 	return s.tmpDir
 }
 
 func (s *Script) Environ(ctx context.Context, args argparse.ParsedArgs) []string {
+	vars, _ := s.EnvironVariables(ctx, args)
+
+	return env.FromVariables(vars)
+}
+
+// EnvironVariables returns environment variables of the script with
+// their sources and a list of reasons why generators have skipped some
+// variables.
+func (s *Script) EnvironVariables( //nolint: funlen
+	ctx context.Context,
+	args argparse.ParsedArgs,
+) ([]env.Variable, []env.Skip) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	environ := []string{
+	vars := env.MakeVariables([]string{
 		env.MakeValue(env.Namespace, s.Namespace),
 		env.MakeValue(env.Caller, s.Executable),
 		env.MakeValue(env.IDRun, s.ID),
@@ -69,53 +124,165 @@ func (s *Script) Environ(ctx context.Context, args argparse.ParsedArgs) []string
 			slug.Make(fmt.Sprintf(
 				"%s-%s-%s-%s",
 				s.Namespace, s.Executable, s.ID, args.ToSlugString()))),
-	}
+	}, env.SourceScript)
+
+	params := []string{}
 
 	for name := range args.Parameters {
-		environ = append(
-			environ,
+		params = append(
+			params,
 			env.MakeValue(
 				env.ParameterName(name),
 				args.GetParameter(name)))
-		environ = append(
-			environ,
+		params = append(
+			params,
 			env.MakeValue(
 				env.ParameterNameList(name),
 				args.GetParameterList(name)))
 	}
 
+	vars = append(vars, env.MakeVariables(params, env.SourceParameter)...)
+
+	flags := []string{}
+
 	for k, v := range args.Flags {
 		if v {
-			environ = append(
-				environ,
+			flags = append(
+				flags,
 				env.MakeValue(env.FlagName(k), argparse.FlagEnabled))
 		}
 	}
 
-	waiterGroup := &sync.WaitGroup{}
-	values := make(chan string, 1)
+	vars = append(vars, env.MakeVariables(flags, env.SourceFlag)...)
+
+	positional := []string{}
+
+	for name, values := range args.NamedPositional(s.Config.Positional) {
+		positional = append(
+			positional,
+			env.MakeValue(env.ArgName(name), strings.Join(values, "\n")))
+	}
+
+	vars = append(vars, env.MakeVariables(positional, env.SourcePositional)...)
+
+	generators := []struct {
+		name     string
+		generate func(context.Context, chan<- string, *sync.WaitGroup)
+	}{
+		{"self", func(ctx context.Context, values chan<- string, waiters *sync.WaitGroup) {
+			env.GenerateSelf(ctx, values, waiters, s.Namespace, s.Executable, args)
+		}},
+		{"time", env.GenerateTime},
+		{"machine_id", env.GenerateMachineID},
+		{"ids", func(ctx context.Context, values chan<- string, waiters *sync.WaitGroup) {
+			env.GenerateIds(ctx, values, waiters, s.Path(), args)
+		}},
+		{"os", env.GenerateOS},
+		{"user", env.GenerateUser},
+		{"hostname", env.GenerateHostname},
+		{"git", func(ctx context.Context, values chan<- string, waiters *sync.WaitGroup) {
+			env.GenerateGit(ctx, values, waiters, s.Config.Git)
+		}},
+		{"network", func(ctx context.Context, values chan<- string, waiters *sync.WaitGroup) {
+			env.GenerateNetwork(ctx, values, waiters, s.Config.Network)
+		}},
+		{"network_ipv6", func(ctx context.Context, values chan<- string, waiters *sync.WaitGroup) {
+			env.GenerateNetworkIPv6(ctx, values, waiters, s.Config.Network)
+		}},
+	}
+
+	skips := []env.Skip{}
+	skipsMutex := &sync.Mutex{}
+	generated := make(chan env.Variable, 1)
+	forwarders := &sync.WaitGroup{}
+
+	for _, generator := range generators {
+		name := generator.name
+		waiterGroup := &sync.WaitGroup{}
+		values := make(chan string, 1)
+		generatorCtx := env.WithSkipReporter(ctx, func(reason string) {
+			skipsMutex.Lock()
+			defer skipsMutex.Unlock()
+
+			skips = append(skips, env.Skip{
+				Generator: name,
+				Reason:    reason,
+			})
+		})
+
+		generator.generate(generatorCtx, values, waiterGroup)
 
-	env.GenerateSelf(ctx, values, waiterGroup, s.Namespace, s.Executable, args)
-	env.GenerateTime(ctx, values, waiterGroup)
-	env.GenerateMachineID(ctx, values, waiterGroup)
-	env.GenerateIds(ctx, values, waiterGroup, s.Path(), args)
-	env.GenerateOS(ctx, values, waiterGroup)
-	env.GenerateUser(ctx, values, waiterGroup)
-	env.GenerateHostname(ctx, values, waiterGroup)
-	env.GenerateGit(ctx, values, waiterGroup, s.Config.Git)
-	env.GenerateNetwork(ctx, values, waiterGroup, s.Config.Network)
-	env.GenerateNetworkIPv6(ctx, values, waiterGroup, s.Config.Network)
+		forwarders.Add(1)
+
+		go func() {
+			waiterGroup.Wait()
+			close(values)
+		}()
+
+		go func() {
+			defer forwarders.Done()
+
+			for _, v := range env.MakeVariables(drain(values), env.SourceGenerator(name)) {
+				generated <- v
+			}
+		}()
+	}
 
 	go func() {
-		waiterGroup.Wait()
-		close(values)
+		forwarders.Wait()
+		close(generated)
 	}()
 
+	for v := range generated {
+		vars = append(vars, v)
+	}
+
+	s.markSensitive(vars, args)
+
+	return vars, skips
+}
+
+// markSensitive marks variables which carry values of sensitive
+// parameters and positionals. These are variables of such arguments
+// and variables which are made of all arguments.
+func (s *Script) markSensitive(vars []env.Variable, args argparse.ParsedArgs) {
+	names := map[string]bool{}
+
+	for name := range args.Parameters {
+		if param, ok := s.Config.Parameters[name]; ok && param.Sensitive() {
+			names[env.ParameterName(name)] = true
+			names[env.ParameterNameList(name)] = true
+		}
+	}
+
+	for idx, arg := range s.Config.Positional {
+		if idx < len(args.Positional) && arg.Parameter != nil && arg.Parameter.Sensitive() {
+			names[env.ArgName(arg.Name)] = true
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	names[env.Self] = true
+	names[env.Slug] = true
+
+	for idx := range vars {
+		if names[vars[idx].Name] {
+			vars[idx].Sensitive = true
+		}
+	}
+}
+
+func drain(values <-chan string) []string {
+	collected := []string{}
+
 	for value := range values {
-		environ = append(environ, value)
+		collected = append(collected, value)
 	}
 
-	return environ
+	return collected
 }
 
 func (s *Script) EnsureDirs() error {
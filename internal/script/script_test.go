@@ -14,6 +14,7 @@
 	"github.com/9seconds/chore/internal/git"
 	"github.com/9seconds/chore/internal/paths"
 	"github.com/9seconds/chore/internal/script"
+	"github.com/9seconds/chore/internal/script/config"
 	"github.com/9seconds/chore/internal/testlib"
 	"github.com/Showmax/go-fqdn"
 	"github.com/adrg/xdg"
@@ -224,6 +225,54 @@ func (suite *ScriptTestSuite) TestEnviron() {
 	suite.Len(data, count)
 }
 
+func (suite *ScriptTestSuite) TestEnvironVariables() {
+	suite.EnsureScript("xx", "1", "echo 1")
+
+	scr, err := script.New("xx", "1")
+	suite.NoError(err)
+
+	scr.Config.Network = false
+	scr.Config.Git = git.AccessModeNo
+	scr.Config.Positional = []config.Positional{
+		{Name: "first"},
+		{Name: "rest", Variadic: true},
+	}
+
+	vars, skips := scr.EnvironVariables(context.Background(), argparse.ParsedArgs{
+		Positional: []string{"a", "b", "c"},
+		Parameters: map[string][]string{
+			"k": {"v"},
+		},
+		Flags: map[string]bool{
+			"cleanup": true,
+		},
+	})
+
+	sources := map[string]string{}
+
+	for _, v := range vars {
+		sources[v.Name] = v.Source
+	}
+
+	suite.Equal(env.SourceScript, sources[env.Namespace])
+	suite.Equal(env.SourceParameter, sources[env.ParameterName("k")])
+	suite.Equal(env.SourceFlag, sources[env.FlagName("cleanup")])
+	suite.Equal(env.SourcePositional, sources[env.ArgName("first")])
+	suite.Equal(env.SourcePositional, sources[env.ArgName("rest")])
+	suite.Equal(env.SourceGenerator("time"), sources[env.StartedAtUnix])
+	suite.Equal(env.SourceGenerator("ids"), sources[env.IDIsolated])
+	suite.NotContains(sources, env.GitReference)
+
+	suite.Contains(skips, env.Skip{
+		Generator: "git",
+		Reason:    "git access mode is no",
+	})
+	suite.Contains(skips, env.Skip{
+		Generator: "network",
+		Reason:    "network access is disabled",
+	})
+}
+
 func TestScript(t *testing.T) {
 	suite.Run(t, &ScriptTestSuite{})
 }
@@ -0,0 +1,263 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/9seconds/chore/internal/dotenv"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatDotenv ExportFormat = "dotenv"
+	ExportFormatJSON   ExportFormat = "json"
+
+	// ExportFormatBundle is a vault of the latest version encrypted with
+	// a passphrase. It is safe to move it between machines.
+	ExportFormatBundle ExportFormat = "bundle"
+)
+
+type ImportStrategy string
+
+const (
+	// ImportStrategyMerge adds new keys and updates existing ones.
+	ImportStrategyMerge ImportStrategy = "merge"
+
+	// ImportStrategyOverwrite replaces the whole content of the vault.
+	ImportStrategyOverwrite ImportStrategy = "overwrite"
+
+	// ImportStrategySkipExisting adds only new keys.
+	ImportStrategySkipExisting ImportStrategy = "skip-existing"
+)
+
+var (
+	ErrInvalidExportFormat   = errors.New("invalid export format")
+	ErrInvalidImportStrategy = errors.New("invalid import strategy")
+	ErrNoMetadataSupport     = errors.New("vault has no metadata support")
+
+	dotenvKeyRegexp     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	dotenvValueReplacer = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`)
+)
+
+// Secret is a value of a secret with its metadata. This is a unit of
+// export and import.
+type Secret struct {
+	Metadata
+
+	Value string `json:"value"`
+}
+
+func (e ExportFormat) String() string {
+	return string(e)
+}
+
+func (e ExportFormat) Valid() bool {
+	switch e {
+	case ExportFormatDotenv, ExportFormatJSON, ExportFormatBundle:
+		return true
+	}
+
+	return false
+}
+
+func GetExportFormat(value string) (ExportFormat, error) {
+	if value == "" {
+		value = ExportFormatDotenv.String()
+	}
+
+	format := ExportFormat(value)
+
+	if !format.Valid() {
+		return "", ErrInvalidExportFormat
+	}
+
+	return format, nil
+}
+
+func (i ImportStrategy) String() string {
+	return string(i)
+}
+
+func (i ImportStrategy) Valid() bool {
+	switch i {
+	case ImportStrategyMerge, ImportStrategyOverwrite, ImportStrategySkipExisting:
+		return true
+	}
+
+	return false
+}
+
+// Apply imports secrets into the vault. It returns a number of keys
+// which were set. Secrets with metadata cannot be imported into vaults
+// which do not support it: access lists or binary flags would be lost.
+func (i ImportStrategy) Apply(vault Vault, secrets map[string]Secret) (int, error) {
+	if !SupportsMetadata(vault) {
+		for key, scrt := range secrets {
+			if !scrt.Metadata.IsZero() {
+				return 0, fmt.Errorf("cannot import metadata of %s: %w", key, ErrNoMetadataSupport)
+			}
+		}
+	}
+
+	if i == ImportStrategyOverwrite {
+		for _, key := range vault.List() {
+			vault.Delete(key)
+		}
+	}
+
+	count := 0
+
+	for key, scrt := range secrets {
+		if _, ok := vault.Get(key); ok && i == ImportStrategySkipExisting {
+			continue
+		}
+
+		vault.Set(key, scrt.Value)
+
+		if !scrt.Metadata.IsZero() {
+			vault.SetMetadata(key, scrt.Metadata)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func GetImportStrategy(value string) (ImportStrategy, error) {
+	if value == "" {
+		value = ImportStrategyMerge.String()
+	}
+
+	strategy := ImportStrategy(value)
+
+	if !strategy.Valid() {
+		return "", ErrInvalidImportStrategy
+	}
+
+	return strategy, nil
+}
+
+// Export writes all secrets of the vault in a given format. Passphrase
+// is used only for bundles.
+func Export(writer io.Writer, vault Vault, format ExportFormat, passphrase string) error {
+	switch format {
+	case ExportFormatDotenv:
+		return exportDotenv(writer, vault)
+	case ExportFormatJSON:
+		return exportJSON(writer, vault)
+	case ExportFormatBundle:
+		bundle, err := Reencrypt(vault, passphrase)
+		if err != nil {
+			return fmt.Errorf("cannot create bundle: %w", err)
+		}
+
+		return Save(writer, bundle)
+	}
+
+	return ErrInvalidExportFormat
+}
+
+// Import reads secrets exported in a given format. Passphrase is used
+// only for bundles. Dotenv has no metadata so its secrets have none.
+func Import(reader io.Reader, format ExportFormat, passphrase string) (map[string]Secret, error) {
+	switch format {
+	case ExportFormatDotenv:
+		environ, err := dotenv.Parse(reader, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse dotenv: %w", err)
+		}
+
+		secrets := make(map[string]Secret, len(environ))
+
+		for _, value := range environ {
+			key, value, _ := strings.Cut(value, "=")
+			secrets[key] = Secret{Value: value}
+		}
+
+		return secrets, nil
+	case ExportFormatJSON:
+		secrets := make(map[string]Secret)
+
+		if err := json.NewDecoder(reader).Decode(&secrets); err != nil {
+			return nil, fmt.Errorf("cannot parse JSON: %w", err)
+		}
+
+		return secrets, nil
+	case ExportFormatBundle:
+		bundle, err := Open(reader, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open bundle: %w", err)
+		}
+
+		return exportSecrets(bundle), nil
+	}
+
+	return nil, ErrInvalidExportFormat
+}
+
+func exportSecrets(vault Vault) map[string]Secret {
+	secrets := make(map[string]Secret)
+
+	for _, key := range vault.List() {
+		value, _ := vault.Get(key)
+		metadata, _ := vault.Metadata(key)
+
+		secrets[key] = Secret{
+			Metadata: metadata,
+			Value:    value,
+		}
+	}
+
+	return secrets
+}
+
+func exportDotenv(writer io.Writer, vault Vault) error {
+	keys := vault.List()
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !dotenvKeyRegexp.MatchString(key) {
+			return fmt.Errorf("key %s cannot be exported to dotenv", key)
+		}
+
+		if metadata, _ := vault.Metadata(key); metadata.Binary {
+			return fmt.Errorf("binary secret %s cannot be exported to dotenv", key)
+		}
+	}
+
+	for _, key := range keys {
+		value, _ := vault.Get(key)
+
+		if _, err := fmt.Fprintf(writer, "%s=\"%s\"\n", key, dotenvValueReplacer.Replace(value)); err != nil {
+			return fmt.Errorf("cannot write data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func exportJSON(writer io.Writer, vault Vault) error {
+	encoder := json.NewEncoder(writer)
+
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(exportSecrets(vault)); err != nil {
+		return fmt.Errorf("cannot encode JSON: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package vault_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/vault"
+	v1 "github.com/9seconds/chore/internal/vault/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+
+	vault vault.Vault
+}
+
+func (suite *ExportTestSuite) SetupTest() {
+	vlt, err := vault.New("pass")
+	suite.NoError(err)
+
+	vlt.Set("k1", "v1")
+	vlt.Set("K_2", "multi\nline \"value\" with $HOME and \\")
+
+	suite.vault = vlt
+}
+
+func (suite *ExportTestSuite) TestRoundTrip() {
+	for _, format := range []vault.ExportFormat{
+		vault.ExportFormatDotenv,
+		vault.ExportFormatJSON,
+		vault.ExportFormatBundle,
+	} {
+		format := format
+
+		suite.T().Run(format.String(), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			assert.NoError(t, vault.Export(buf, suite.vault, format, "passphrase"))
+			assert.NotContains(t, buf.String(), "pass\n")
+
+			secrets, err := vault.Import(buf, format, "passphrase")
+			assert.NoError(t, err)
+
+			values := map[string]string{}
+
+			for key, scrt := range secrets {
+				values[key] = scrt.Value
+			}
+
+			assert.Equal(t, map[string]string{
+				"k1":  "v1",
+				"K_2": "multi\nline \"value\" with $HOME and \\",
+			}, values)
+		})
+	}
+}
+
+func (suite *ExportTestSuite) TestMetadataRoundTrip() {
+	metadata := vault.Metadata{
+		Created:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Updated:     time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		Description: "description",
+		Expires:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Scripts:     []string{"s"},
+		Binary:      true,
+	}
+
+	suite.vault.Set("bin", vault.EncodeBinary([]byte{0, 1}))
+	suite.vault.SetMetadata("bin", metadata)
+
+	for _, format := range []vault.ExportFormat{vault.ExportFormatJSON, vault.ExportFormatBundle} {
+		format := format
+
+		suite.T().Run(format.String(), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			assert.NoError(t, vault.Export(buf, suite.vault, format, "passphrase"))
+
+			secrets, err := vault.Import(buf, format, "passphrase")
+			assert.NoError(t, err)
+			assert.Equal(t, metadata, secrets["bin"].Metadata)
+
+			vlt, err := vault.New("pass")
+			assert.NoError(t, err)
+
+			_, err = vault.ImportStrategyMerge.Apply(vlt, secrets)
+			assert.NoError(t, err)
+
+			actual, _ := vlt.Metadata("bin")
+			assert.Equal(t, metadata, actual)
+		})
+	}
+}
+
+func (suite *ExportTestSuite) TestDotenvBinary() {
+	suite.vault.Set("BIN", vault.EncodeBinary([]byte{0, 1}))
+	suite.vault.SetMetadata("BIN", vault.Metadata{Binary: true})
+
+	suite.ErrorContains(
+		vault.Export(&bytes.Buffer{}, suite.vault, vault.ExportFormatDotenv, ""),
+		"binary secret BIN")
+}
+
+func (suite *ExportTestSuite) TestImportMetadataIntoV1() {
+	vlt, err := v1.NewVault("pass")
+	suite.NoError(err)
+
+	_, err = vault.ImportStrategyMerge.Apply(vlt, map[string]vault.Secret{
+		"k": {Value: "v", Metadata: vault.Metadata{Binary: true}},
+	})
+	suite.ErrorIs(err, vault.ErrNoMetadataSupport)
+	suite.Empty(vlt.List())
+
+	count, err := vault.ImportStrategyMerge.Apply(vlt, map[string]vault.Secret{"k": {Value: "v"}})
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *ExportTestSuite) TestDotenv() {
+	buf := &bytes.Buffer{}
+
+	suite.NoError(vault.Export(buf, suite.vault, vault.ExportFormatDotenv, ""))
+	suite.Equal("K_2=\"multi\\nline \\\"value\\\" with \\$HOME and \\\\\"\nk1=\"v1\"\n", buf.String())
+}
+
+func (suite *ExportTestSuite) TestDotenvIncorrectKey() {
+	suite.vault.Set("my-key", "v")
+
+	suite.ErrorContains(
+		vault.Export(&bytes.Buffer{}, suite.vault, vault.ExportFormatDotenv, ""),
+		"my-key")
+}
+
+func (suite *ExportTestSuite) TestBundleWrongPassphrase() {
+	buf := &bytes.Buffer{}
+
+	suite.NoError(vault.Export(buf, suite.vault, vault.ExportFormatBundle, "passphrase"))
+
+	_, err := vault.Import(buf, vault.ExportFormatBundle, "pass")
+	suite.ErrorContains(err, "bad password")
+}
+
+func (suite *ExportTestSuite) TestStrategies() {
+	secrets := map[string]vault.Secret{"k1": {Value: "new"}, "k3": {Value: "v3"}}
+
+	testTable := map[vault.ImportStrategy]map[string]string{
+		vault.ImportStrategyMerge: {
+			"k1":  "new",
+			"K_2": "multi\nline \"value\" with $HOME and \\",
+			"k3":  "v3",
+		},
+		vault.ImportStrategyOverwrite: {
+			"k1": "new",
+			"k3": "v3",
+		},
+		vault.ImportStrategySkipExisting: {
+			"k1":  "v1",
+			"K_2": "multi\nline \"value\" with $HOME and \\",
+			"k3":  "v3",
+		},
+	}
+
+	for strategy, expected := range testTable {
+		strategy := strategy
+		expected := expected
+
+		suite.T().Run(strategy.String(), func(t *testing.T) {
+			vlt, err := vault.Reencrypt(suite.vault, "pass")
+			assert.NoError(t, err)
+
+			_, err = strategy.Apply(vlt, secrets)
+			assert.NoError(t, err)
+
+			actual := map[string]string{}
+
+			for _, key := range vlt.List() {
+				actual[key], _ = vlt.Get(key)
+			}
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func (suite *ExportTestSuite) TestGetFormatAndStrategy() {
+	format, err := vault.GetExportFormat("")
+	suite.NoError(err)
+	suite.Equal(vault.ExportFormatDotenv, format)
+
+	_, err = vault.GetExportFormat("xml")
+	suite.ErrorIs(err, vault.ErrInvalidExportFormat)
+
+	strategy, err := vault.GetImportStrategy("")
+	suite.NoError(err)
+	suite.Equal(vault.ImportStrategyMerge, strategy)
+
+	_, err = vault.GetImportStrategy("xxx")
+	suite.ErrorIs(err, vault.ErrInvalidImportStrategy)
+}
+
+func TestExport(t *testing.T) {
+	suite.Run(t, &ExportTestSuite{})
+}
@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OpenFile opens a vault stored in a file. If file does not exist, a new
+// empty vault is returned.
+func OpenFile(path, password string) (Vault, error) {
+	reader, err := os.Open(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return New(password)
+	case err != nil:
+		return nil, fmt.Errorf("cannot open vault: %w", err)
+	}
+
+	defer reader.Close()
+
+	return Open(reader, password)
+}
+
+// SaveFile atomically writes a vault to a file.
+func SaveFile(path string, vault Vault) error {
+	buf := &bytes.Buffer{}
+
+	if err := Save(buf, vault); err != nil {
+		return err
+	}
+
+	return WriteFile(path, buf.Bytes())
+}
+
+// BackupFile copies a file to a backup path. If file does not exist,
+// nothing is done and false is returned.
+func BackupFile(path, backupPath string) (bool, error) {
+	data, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("cannot read file: %w", err)
+	}
+
+	if err := WriteFile(backupPath, data); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// WriteFile atomically writes data to a file which is readable only by
+// its owner: data is written into a temporary file in the same
+// directory which is renamed then.
+func WriteFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file: %w", err)
+	}
+
+	defer os.Remove(file.Name()) //nolint: errcheck
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+
+		return fmt.Errorf("cannot write data: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return fmt.Errorf("cannot sync data: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("cannot close file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("cannot move file: %w", err)
+	}
+
+	return nil
+}
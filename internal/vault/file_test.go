@@ -0,0 +1,90 @@
+package vault_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/9seconds/chore/internal/vault"
+	"github.com/stretchr/testify/suite"
+)
+
+type FileTestSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (suite *FileTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "vault")
+}
+
+func (suite *FileTestSuite) TestOpenAbsent() {
+	box, err := vault.OpenFile(suite.path, "pass")
+	suite.NoError(err)
+	suite.Empty(box.List())
+	suite.NoFileExists(suite.path)
+}
+
+func (suite *FileTestSuite) TestSaveOpen() {
+	box, err := vault.OpenFile(suite.path, "pass")
+	suite.NoError(err)
+
+	box.Set("k", "v")
+	suite.NoError(vault.SaveFile(suite.path, box))
+
+	box, err = vault.OpenFile(suite.path, "pass")
+	suite.NoError(err)
+
+	value, ok := box.Get("k")
+	suite.True(ok)
+	suite.Equal("v", value)
+
+	_, err = vault.OpenFile(suite.path, "bad-password")
+	suite.ErrorContains(err, "password")
+}
+
+func (suite *FileTestSuite) TestCannotSave() {
+	box, err := vault.New("pass")
+	suite.NoError(err)
+
+	suite.Error(vault.SaveFile(filepath.Join(suite.path, "x", "y"), box))
+}
+
+func (suite *FileTestSuite) TestSavePermissions() {
+	box, err := vault.New("pass")
+	suite.NoError(err)
+
+	suite.NoError(vault.SaveFile(suite.path, box))
+
+	stat, err := os.Stat(suite.path)
+	suite.NoError(err)
+	suite.EqualValues(0o600, stat.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(suite.path))
+	suite.NoError(err)
+	suite.Len(entries, 1)
+}
+
+func (suite *FileTestSuite) TestBackup() {
+	backupPath := suite.path + ".bak"
+
+	ok, err := vault.BackupFile(suite.path, backupPath)
+	suite.NoError(err)
+	suite.False(ok)
+	suite.NoFileExists(backupPath)
+
+	suite.NoError(os.WriteFile(suite.path, []byte("data"), 0o600))
+
+	ok, err = vault.BackupFile(suite.path, backupPath)
+	suite.NoError(err)
+	suite.True(ok)
+
+	data, err := os.ReadFile(backupPath)
+	suite.NoError(err)
+	suite.Equal("data", string(data))
+}
+
+func TestFile(t *testing.T) {
+	suite.Run(t, &FileTestSuite{})
+}
@@ -0,0 +1,66 @@
+package meta
+
+import (
+	"encoding/base64"
+	"time"
+)
+
+// Metadata describes a secret of a vault. Zero time values mean that
+// a value is unknown (e.g, for vaults which do not support metadata)
+// or not set.
+type Metadata struct {
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	Description string    `json:"description,omitempty"`
+	Expires     time.Time `json:"expires"`
+
+	// Scripts is a list of scripts of the namespace which are allowed
+	// to read the secret. Empty list means that any script can do that.
+	Scripts []string `json:"scripts,omitempty"`
+
+	// Binary tells that a value is base64-encoded binary data.
+	Binary bool `json:"binary,omitempty"`
+}
+
+// EncodeBinary encodes binary data into a value of a secret.
+func EncodeBinary(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// Decode returns raw content of a secret value.
+func (m Metadata) Decode(value string) ([]byte, error) {
+	if !m.Binary {
+		return []byte(value), nil
+	}
+
+	return base64.StdEncoding.DecodeString(value)
+}
+
+func (m Metadata) IsZero() bool {
+	return m.Created.IsZero() &&
+		m.Updated.IsZero() &&
+		m.Description == "" &&
+		m.Expires.IsZero() &&
+		len(m.Scripts) == 0 &&
+		!m.Binary
+}
+
+// AllowsScript tells if a script can read the secret.
+func (m Metadata) AllowsScript(name string) bool {
+	if len(m.Scripts) == 0 {
+		return true
+	}
+
+	for _, allowed := range m.Scripts {
+		if allowed == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsExpired tells if a secret has expired at a given moment.
+func (m Metadata) IsExpired(now time.Time) bool {
+	return !m.Expires.IsZero() && !now.Before(m.Expires)
+}
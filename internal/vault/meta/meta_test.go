@@ -0,0 +1,45 @@
+package meta_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/vault/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExpired(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, meta.Metadata{}.IsExpired(now))
+	assert.False(t, meta.Metadata{Expires: now.Add(time.Hour)}.IsExpired(now))
+	assert.True(t, meta.Metadata{Expires: now}.IsExpired(now))
+	assert.True(t, meta.Metadata{Expires: now.Add(-time.Hour)}.IsExpired(now))
+}
+
+func TestIsZero(t *testing.T) {
+	assert.True(t, meta.Metadata{}.IsZero())
+	assert.False(t, meta.Metadata{Description: "x"}.IsZero())
+	assert.False(t, meta.Metadata{Scripts: []string{"x"}}.IsZero())
+}
+
+func TestAllowsScript(t *testing.T) {
+	assert.True(t, meta.Metadata{}.AllowsScript("x"))
+	assert.True(t, meta.Metadata{Scripts: []string{"y", "x"}}.AllowsScript("x"))
+	assert.False(t, meta.Metadata{Scripts: []string{"y"}}.AllowsScript("x"))
+}
+
+func TestDecode(t *testing.T) {
+	data := []byte{0, 1, 0xff}
+
+	decoded, err := meta.Metadata{Binary: true}.Decode(meta.EncodeBinary(data))
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	decoded, err = meta.Metadata{}.Decode("value")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), decoded)
+
+	_, err = meta.Metadata{Binary: true}.Decode("???")
+	assert.Error(t, err)
+}
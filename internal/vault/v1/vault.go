@@ -8,6 +8,8 @@
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/9seconds/chore/internal/vault/meta"
 )
 
 // format is very simple:
@@ -129,6 +131,16 @@ func (v *Vault) Get(key string) (string, bool) {
 	return value, ok
 }
 
+// Metadata returns empty metadata: v1 vaults do not support it.
+func (v *Vault) Metadata(key string) (meta.Metadata, bool) {
+	_, ok := v.data[key]
+
+	return meta.Metadata{}, ok
+}
+
+// SetMetadata does nothing: v1 vaults do not support metadata.
+func (v *Vault) SetMetadata(string, meta.Metadata) {}
+
 func (v *Vault) Delete(key string) {
 	delete(v.data, key)
 }
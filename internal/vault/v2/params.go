@@ -0,0 +1,82 @@
+package v2
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	KeyLength  = 32 // chacha20poly1305.KeySize
+	SaltLength = 16
+
+	// ParamsLength is a length of serialized parameters:
+	// time (uint32), memory (uint32) and threads (uint8).
+	ParamsLength = 4 + 4 + 1
+
+	// Params are read from unauthenticated header before a password is
+	// checked so upper limits have to be modest.
+	MinTime    = 1
+	MaxTime    = 16
+	MaxMemory  = 1024 * 1024 // 1GiB
+	MinThreads = 1
+	MaxThreads = 16
+)
+
+// Params are tunable Argon2id parameters. They are stored in the vault
+// header so they could be changed without breaking existing vaults.
+type Params struct {
+	// Time is a number of passes over the memory.
+	Time uint32
+
+	// Memory is a size of the memory in KiB.
+	Memory uint32
+
+	// Threads is a degree of parallelism.
+	Threads uint8
+}
+
+// DefaultParams are the second recommended option of RFC 9106.
+var DefaultParams = Params{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// Validate checks that parameters are sane. Upper limits protect from
+// tampered vaults which could make chore allocate gigabytes of memory.
+func (p Params) Validate() error {
+	switch {
+	case p.Time < MinTime || p.Time > MaxTime:
+		return fmt.Errorf("time %d is out of [%d, %d]: %w", p.Time, MinTime, MaxTime, ErrIncorrectParams)
+	case p.Threads < MinThreads || p.Threads > MaxThreads:
+		return fmt.Errorf("threads %d is out of [%d, %d]: %w", p.Threads, MinThreads, MaxThreads, ErrIncorrectParams)
+	case p.Memory < 8*uint32(p.Threads) || p.Memory > MaxMemory:
+		return fmt.Errorf("memory %d KiB is out of [%d, %d]: %w", p.Memory, 8*uint32(p.Threads), MaxMemory, ErrIncorrectParams)
+	}
+
+	return nil
+}
+
+func (p Params) deriveKey(password, salt []byte) []byte {
+	return argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, KeyLength)
+}
+
+func (p Params) marshal() []byte {
+	data := make([]byte, ParamsLength)
+
+	binary.LittleEndian.PutUint32(data[0:4], p.Time)
+	binary.LittleEndian.PutUint32(data[4:8], p.Memory)
+	data[8] = p.Threads
+
+	return data
+}
+
+func unmarshalParams(data []byte) Params {
+	return Params{
+		Time:    binary.LittleEndian.Uint32(data[0:4]),
+		Memory:  binary.LittleEndian.Uint32(data[4:8]),
+		Threads: data[8],
+	}
+}
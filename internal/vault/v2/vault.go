@@ -0,0 +1,201 @@
+package v2
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/9seconds/chore/internal/vault/meta"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// format is:
+// [params | salt | nonce | ciphertext]
+//
+// params are Argon2id parameters: time (uint32 little endian), memory
+//    in KiB (uint32 little endian) and threads (uint8). 9 bytes
+// salt is Argon2id salt. A key is derived from a password and salt. 16 bytes
+// nonce is XChaCha20-Poly1305 nonce. 24 bytes
+// ciphertext is XChaCha20-Poly1305 encrypted JSON mapping of a key to
+//    a secret (a value with metadata) with 16 bytes of authentication
+//    tag.
+//
+// A version byte, params and salt are authenticated as additional data
+// so neither of them can be changed without a password. Salt and nonce
+// are regenerated on each save.
+
+const Version = 2
+
+const (
+	NonceLength  = chacha20poly1305.NonceSizeX
+	HeaderLength = ParamsLength + SaltLength + NonceLength
+)
+
+var (
+	ErrEmptyPassword   = errors.New("password is empty")
+	ErrBadPassword     = errors.New("bad password")
+	ErrShortData       = errors.New("encrypted data is short")
+	ErrIncorrectParams = errors.New("incorrect KDF parameters")
+)
+
+type secret struct {
+	meta.Metadata
+
+	Value string `json:"value"`
+}
+
+type Vault struct {
+	password []byte
+	params   Params
+	data     map[string]secret
+}
+
+func (v *Vault) UnmarshalBinary(data []byte) error {
+	if len(data) < HeaderLength+chacha20poly1305.Overhead {
+		return fmt.Errorf("cannot read header: %w", ErrShortData)
+	}
+
+	params := unmarshalParams(data[:ParamsLength])
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	salt := data[ParamsLength : ParamsLength+SaltLength]
+	nonce := data[ParamsLength+SaltLength : HeaderLength]
+
+	aead, err := chacha20poly1305.NewX(params.deriveKey(v.password, salt))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	message, err := aead.Open(nil, nonce, data[HeaderLength:], additionalData(data))
+	if err != nil {
+		return ErrBadPassword
+	}
+
+	values := make(map[string]secret)
+
+	if err := json.Unmarshal(message, &values); err != nil {
+		return fmt.Errorf("cannot decode message: %w", err)
+	}
+
+	v.params = params
+	v.data = values
+
+	return nil
+}
+
+func (v *Vault) MarshalBinary() ([]byte, error) {
+	message, err := json.Marshal(v.data)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	result := make([]byte, 0, HeaderLength+len(message)+chacha20poly1305.Overhead)
+	result = append(result, v.params.marshal()...)
+	result = append(result, randomBytes(SaltLength)...)
+	result = append(result, randomBytes(NonceLength)...)
+
+	salt := result[ParamsLength : ParamsLength+SaltLength]
+	nonce := result[ParamsLength+SaltLength : HeaderLength]
+
+	aead, err := chacha20poly1305.NewX(v.params.deriveKey(v.password, salt))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return aead.Seal(result, nonce, message, additionalData(result)), nil
+}
+
+func (v *Vault) Version() uint8 {
+	return Version
+}
+
+func (v *Vault) Params() Params {
+	return v.params
+}
+
+func (v *Vault) List() []string {
+	items := make([]string, 0, len(v.data))
+
+	for k := range v.data {
+		items = append(items, k)
+	}
+
+	return items
+}
+
+// Set sets a value of the secret and updates its timestamps.
+func (v *Vault) Set(key, value string) {
+	now := time.Now().UTC()
+	scrt, ok := v.data[key]
+
+	if !ok {
+		scrt.Created = now
+	}
+
+	scrt.Updated = now
+	scrt.Value = value
+	v.data[key] = scrt
+}
+
+func (v *Vault) Get(key string) (string, bool) {
+	scrt, ok := v.data[key]
+
+	return scrt.Value, ok
+}
+
+func (v *Vault) Metadata(key string) (meta.Metadata, bool) {
+	scrt, ok := v.data[key]
+
+	return scrt.Metadata, ok
+}
+
+// SetMetadata replaces metadata of an existing secret.
+func (v *Vault) SetMetadata(key string, metadata meta.Metadata) {
+	if scrt, ok := v.data[key]; ok {
+		scrt.Metadata = metadata
+		v.data[key] = scrt
+	}
+}
+
+func (v *Vault) Delete(key string) {
+	delete(v.data, key)
+}
+
+// additionalData returns a version byte, params and salt.
+func additionalData(data []byte) []byte {
+	return append([]byte{Version}, data[:ParamsLength+SaltLength]...)
+}
+
+func randomBytes(length int) []byte {
+	data := make([]byte, length)
+
+	if _, err := rand.Read(data); err != nil {
+		panic(err.Error())
+	}
+
+	return data
+}
+
+func NewVault(password string) (*Vault, error) {
+	return NewVaultWithParams(password, DefaultParams)
+}
+
+func NewVaultWithParams(password string, params Params) (*Vault, error) {
+	if password == "" {
+		return nil, ErrEmptyPassword
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Vault{
+		password: []byte(password),
+		params:   params,
+		data:     make(map[string]secret),
+	}, nil
+}
@@ -0,0 +1,235 @@
+package v2_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/9seconds/chore/internal/testlib"
+	"github.com/9seconds/chore/internal/vault/meta"
+	v2 "github.com/9seconds/chore/internal/vault/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type VaultTestSuite struct {
+	suite.Suite
+
+	testlib.FixturesTestSuite
+}
+
+func (suite *VaultTestSuite) SetupTest() {
+	suite.FixturesTestSuite.Setup(suite.T())
+}
+
+func (suite *VaultTestSuite) TestNewWithEmptyPassword() {
+	_, err := v2.NewVault("")
+	suite.ErrorIs(err, v2.ErrEmptyPassword)
+}
+
+func (suite *VaultTestSuite) TestNewWithIncorrectParams() {
+	testTable := map[string]v2.Params{
+		"time":    {Time: 0, Memory: 1024, Threads: 1},
+		"threads": {Time: 1, Memory: 1024, Threads: 0},
+		"memory":  {Time: 1, Memory: 8, Threads: 2},
+		"huge":    {Time: 1, Memory: v2.MaxMemory + 1, Threads: 1},
+	}
+
+	for testName, testValue := range testTable {
+		testValue := testValue
+
+		suite.T().Run(testName, func(t *testing.T) {
+			_, err := v2.NewVaultWithParams("pass", testValue)
+			assert.ErrorIs(t, err, v2.ErrIncorrectParams)
+		})
+	}
+}
+
+func (suite *VaultTestSuite) TestVersion() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	suite.EqualValues(2, vault.Version())
+	suite.Equal(v2.DefaultParams, vault.Params())
+}
+
+func (suite *VaultTestSuite) TestSetGetDel() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	vault.Set("k1", "v1")
+
+	value, exists := vault.Get("k1")
+	suite.True(exists)
+	suite.Equal("v1", value)
+
+	vault.Delete("k1")
+
+	_, exists = vault.Get("k1")
+	suite.False(exists)
+}
+
+func (suite *VaultTestSuite) TestList() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	suite.Empty(vault.List())
+
+	vault.Set("k1", "v1")
+	vault.Set("k2", "v1")
+
+	list := vault.List()
+	sort.Strings(list)
+	suite.Equal([]string{"k1", "k2"}, list)
+}
+
+func (suite *VaultTestSuite) TestRestore() {
+	params := v2.Params{Time: 1, Memory: 1024, Threads: 1}
+
+	vault, err := v2.NewVaultWithParams("pass", params)
+	suite.NoError(err)
+
+	vault.Set("k1", "v1")
+
+	data, err := vault.MarshalBinary()
+	suite.NoError(err)
+	suite.NotContains(string(data), "k1")
+	suite.NotContains(string(data), "v1")
+
+	data2, err := vault.MarshalBinary()
+	suite.NoError(err)
+	suite.NotEqual(data, data2)
+
+	newVault, err := v2.NewVault("pass")
+	suite.NoError(err)
+	suite.NoError(newVault.UnmarshalBinary(data))
+	suite.Equal(params, newVault.Params())
+
+	value, ok := newVault.Get("k1")
+	suite.True(ok)
+	suite.Equal("v1", value)
+}
+
+func (suite *VaultTestSuite) TestBadPassword() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	data, err := vault.MarshalBinary()
+	suite.NoError(err)
+
+	newVault, err := v2.NewVault("pass2")
+	suite.NoError(err)
+	suite.ErrorIs(newVault.UnmarshalBinary(data), v2.ErrBadPassword)
+}
+
+func (suite *VaultTestSuite) TestTamperedHeader() {
+	vault, err := v2.NewVaultWithParams("pass", v2.Params{Time: 1, Memory: 1024, Threads: 1})
+	suite.NoError(err)
+
+	data, err := vault.MarshalBinary()
+	suite.NoError(err)
+
+	// time 1 -> 2
+	data[0] = 2
+
+	suite.ErrorIs(vault.UnmarshalBinary(data), v2.ErrBadPassword)
+}
+
+func (suite *VaultTestSuite) TestIncorrectParams() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	data := bytes.Repeat([]byte{0xff}, v2.HeaderLength+16)
+
+	suite.ErrorIs(vault.UnmarshalBinary(data), v2.ErrIncorrectParams)
+}
+
+func (suite *VaultTestSuite) TestShortData() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	suite.ErrorIs(
+		vault.UnmarshalBinary(bytes.Repeat([]byte{1}, v2.HeaderLength)),
+		v2.ErrShortData)
+}
+
+func (suite *VaultTestSuite) TestReadCorrectSnapshot() {
+	vault, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	vault.Set("k1", "v1")
+
+	marshalled, _ := vault.MarshalBinary()
+	suite.EnsureSnapshot(marshalled, "correct-snapshot")
+
+	vault, err = v2.NewVault("pass")
+	suite.NoError(err)
+
+	data := suite.ReadPath("correct-snapshot")
+	suite.NoError(vault.UnmarshalBinary(data))
+
+	value, ok := vault.Get("k1")
+	suite.True(ok)
+	suite.Equal("v1", value)
+}
+
+func (suite *VaultTestSuite) TestMetadata() {
+	vault, err := v2.NewVaultWithParams("pass", v2.Params{Time: 1, Memory: 1024, Threads: 1})
+	suite.NoError(err)
+
+	_, ok := vault.Metadata("k1")
+	suite.False(ok)
+
+	vault.SetMetadata("k1", meta.Metadata{Description: "xxx"})
+
+	_, ok = vault.Metadata("k1")
+	suite.False(ok)
+
+	before := time.Now()
+
+	vault.Set("k1", "v1")
+
+	metadata, ok := vault.Metadata("k1")
+	suite.True(ok)
+	suite.False(metadata.Created.Before(before))
+	suite.Equal(metadata.Created, metadata.Updated)
+
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	metadata.Description = "token"
+	metadata.Expires = expires
+
+	vault.SetMetadata("k1", metadata)
+	vault.Set("k1", "v2")
+
+	updated, ok := vault.Metadata("k1")
+	suite.True(ok)
+	suite.Equal(metadata.Created, updated.Created)
+	suite.True(updated.Updated.After(metadata.Updated) || updated.Updated.Equal(metadata.Updated))
+	suite.Equal("token", updated.Description)
+
+	data, err := vault.MarshalBinary()
+	suite.NoError(err)
+
+	restored, err := v2.NewVault("pass")
+	suite.NoError(err)
+	suite.NoError(restored.UnmarshalBinary(data))
+
+	restoredMetadata, ok := restored.Metadata("k1")
+	suite.True(ok)
+	suite.Equal("token", restoredMetadata.Description)
+	suite.True(expires.Equal(restoredMetadata.Expires))
+	suite.True(updated.Created.Equal(restoredMetadata.Created))
+}
+
+func (suite *VaultTestSuite) TestSnapshotWithWrongPassword() {
+	vault, err := v2.NewVault("pass2")
+	suite.NoError(err)
+
+	data := suite.ReadPath("correct-snapshot")
+	suite.ErrorIs(vault.UnmarshalBinary(data), v2.ErrBadPassword)
+}
+
+func TestVault(t *testing.T) {
+	suite.Run(t, &VaultTestSuite{})
+}
@@ -7,15 +7,29 @@
 	"fmt"
 	"io"
 
+	"github.com/9seconds/chore/internal/vault/meta"
 	v1 "github.com/9seconds/chore/internal/vault/v1"
+	v2 "github.com/9seconds/chore/internal/vault/v2"
 )
 
 var (
 	ErrEmptySecret             = errors.New("secret should not be empty")
 	ErrUnsupportedVaultVersion = errors.New("vault version is not supported")
-	LatestVersion              = 1
+	LatestVersion              = v2.Version
+	DefaultParams              = v2.DefaultParams
 )
 
+type Metadata = meta.Metadata
+
+// Params are KDF parameters of the latest vault version.
+type Params = v2.Params
+
+// EncodeBinary encodes binary data into a value of a secret. Such
+// secrets must have Binary flag in metadata.
+func EncodeBinary(data []byte) string {
+	return meta.EncodeBinary(data)
+}
+
 type Vault interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
@@ -24,6 +38,8 @@ type Vault interface {
 	List() []string
 	Set(key, value string)
 	Get(key string) (string, bool)
+	Metadata(key string) (Metadata, bool)
+	SetMetadata(key string, metadata Metadata)
 	Delete(key string)
 }
 
@@ -45,6 +61,8 @@ func Open(reader io.Reader, password string) (Vault, error) {
 	switch version {
 	case 1:
 		vault, err = v1.NewVault(password)
+	case v2.Version:
+		vault, err = v2.NewVault(password)
 	default:
 		return nil, ErrUnsupportedVaultVersion
 	}
@@ -61,7 +79,53 @@ func Open(reader io.Reader, password string) (Vault, error) {
 }
 
 func New(password string) (Vault, error) {
-	return v1.NewVault(password)
+	return v2.NewVault(password)
+}
+
+func NewWithParams(password string, params Params) (Vault, error) {
+	return v2.NewVaultWithParams(password, params)
+}
+
+// GetParams returns KDF parameters of the vault. Vaults of old versions
+// have no tunable parameters.
+func GetParams(vault Vault) (Params, bool) {
+	if box, ok := vault.(*v2.Vault); ok {
+		return box.Params(), true
+	}
+
+	return Params{}, false
+}
+
+// SupportsMetadata tells if a vault stores metadata of secrets.
+func SupportsMetadata(vault Vault) bool {
+	return vault.Version() >= v2.Version
+}
+
+// Reencrypt creates a vault of the latest version with the same secrets
+// and a given password.
+func Reencrypt(vault Vault, password string) (Vault, error) {
+	return ReencryptWithParams(vault, password, DefaultParams)
+}
+
+// ReencryptWithParams is Reencrypt which uses given KDF parameters.
+func ReencryptWithParams(vault Vault, password string, params Params) (Vault, error) {
+	reencrypted, err := NewWithParams(password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range vault.List() {
+		value, _ := vault.Get(key)
+		metadata, _ := vault.Metadata(key)
+
+		reencrypted.Set(key, value)
+
+		if !metadata.IsZero() {
+			reencrypted.SetMetadata(key, metadata)
+		}
+	}
+
+	return reencrypted, nil
 }
 
 func Save(writer io.Writer, vault Vault) error {
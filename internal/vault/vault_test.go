@@ -10,6 +10,7 @@
 	"github.com/9seconds/chore/internal/testlib"
 	"github.com/9seconds/chore/internal/vault"
 	v1 "github.com/9seconds/chore/internal/vault/v1"
+	v2 "github.com/9seconds/chore/internal/vault/v2"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -135,6 +136,25 @@ func (suite *VaultV1Test) TestReadOk() {
 	suite.Equal("vv1", value)
 }
 
+func (suite *VaultV1Test) TestUpgrade() {
+	fp, err := os.Open(suite.FixturePath("v1-snapshot"))
+	suite.NoError(err)
+
+	defer fp.Close()
+
+	box, err := vault.Open(fp, "pass")
+	suite.NoError(err)
+	suite.EqualValues(1, box.Version())
+
+	upgraded, err := vault.Reencrypt(box, "pass")
+	suite.NoError(err)
+	suite.EqualValues(vault.LatestVersion, upgraded.Version())
+
+	value, ok := upgraded.Get("k1")
+	suite.True(ok)
+	suite.Equal("vv1", value)
+}
+
 func (suite *VaultV1Test) TestReadFail() {
 	fp, err := os.Open(suite.FixturePath("v1-snapshot"))
 	suite.NoError(err)
@@ -145,6 +165,41 @@ func (suite *VaultV1Test) TestReadFail() {
 	suite.ErrorContains(err, "password")
 }
 
+type VaultV2Test struct {
+	suite.Suite
+
+	testlib.FixturesTestSuite
+}
+
+func (suite *VaultV2Test) SetupTest() {
+	suite.FixturesTestSuite.Setup(suite.T())
+
+	box, err := v2.NewVault("pass")
+	suite.NoError(err)
+
+	box.Set("k1", "vv1")
+
+	data := &bytes.Buffer{}
+	suite.NoError(vault.Save(data, box))
+
+	suite.EnsureSnapshot(data.Bytes(), "v2-snapshot")
+}
+
+func (suite *VaultV2Test) TestReadOk() {
+	fp, err := os.Open(suite.FixturePath("v2-snapshot"))
+	suite.NoError(err)
+
+	defer fp.Close()
+
+	box, err := vault.Open(fp, "pass")
+	suite.NoError(err)
+	suite.EqualValues(2, box.Version())
+
+	value, ok := box.Get("k1")
+	suite.True(ok)
+	suite.Equal("vv1", value)
+}
+
 func TestBaseVault(t *testing.T) {
 	suite.Run(t, &BaseVaultTest{})
 }
@@ -152,3 +207,7 @@ func TestBaseVault(t *testing.T) {
 func TestVaultV1(t *testing.T) {
 	suite.Run(t, &VaultV1Test{})
 }
+
+func TestVaultV2(t *testing.T) {
+	suite.Run(t, &VaultV2Test{})
+}
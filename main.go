@@ -8,12 +8,19 @@
 	"syscall"
 
 	"github.com/9seconds/chore/internal/cli"
+	"github.com/9seconds/chore/internal/commands"
 	"github.com/gosimple/slug"
 )
 
 var version = "dev"
 
 func main() {
+	// chore executes itself as a shim to set resource limits of
+	// a script. This has to happen before anything else.
+	if len(os.Args) > 1 && os.Args[1] == commands.LimitsShimCommand {
+		commands.RunLimitsShim(os.Args[2:])
+	}
+
 	slug.Lowercase = false
 	slug.MaxLength = 100
 
@@ -44,6 +51,10 @@ func main() {
 		cli.NewRemove(),
 		cli.NewRename(),
 		cli.NewShow(),
+		cli.NewHistory(),
+		cli.NewLogs(),
+		cli.NewSchedule(),
+		cli.NewScheduler(),
 		cli.NewVault(),
 		cli.NewGC(),
 		cli.NewUpdate())